@@ -128,6 +128,27 @@ func BenchmarkMinimalPreparedSelect(b *testing.B) {
 	}
 }
 
+// BenchmarkInsertLargeBytea measures the memory used to send a 10MB bytea parameter. The parameter is not copied into
+// the ExtendedQueryBuilder but the complete Bind message is still buffered before it is sent.
+func BenchmarkInsertLargeBytea(b *testing.B) {
+	conn := mustConnect(b, mustParseConfig(b, os.Getenv("PGX_TEST_DATABASE")))
+	defer closeConn(b, conn)
+
+	mustExec(b, conn, "create temporary table t (data bytea)")
+
+	data := bytes.Repeat([]byte{0xAB}, 10*1024*1024)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := conn.Exec(context.Background(), "insert into t (data) values ($1)", data)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func BenchmarkMinimalPgConnPreparedSelect(b *testing.B) {
 	conn := mustConnect(b, mustParseConfig(b, os.Getenv("PGX_TEST_DATABASE")))
 	defer closeConn(b, conn)
@@ -1067,6 +1088,51 @@ func BenchmarkSelectRowsScanDecoder(b *testing.B) {
 	}
 }
 
+const benchSelectRowsMapSQL = "select n, 'Adam', 'Smith ' || n, 'male', '1952-06-16'::date, 258, 72, '2001-01-28 01:02:03-05'::timestamptz from generate_series(100001, 110000) n"
+
+func BenchmarkSelectRowsRowToMap(b *testing.B) {
+	conn := mustConnectString(b, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(b, conn)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		rows, _ := conn.Query(context.Background(), benchSelectRowsMapSQL)
+		var count int
+		_, err := pgx.ForEachRow(rows, nil, func() error {
+			_, err := pgx.RowToMap(rows)
+			count++
+			return err
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if count != 10000 {
+			b.Fatalf("expected 10000 rows, got %d", count)
+		}
+	}
+}
+
+func BenchmarkSelectRowsForEachRowMap(b *testing.B) {
+	conn := mustConnectString(b, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(b, conn)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		rows, _ := conn.Query(context.Background(), benchSelectRowsMapSQL)
+		var count int
+		_, err := pgx.ForEachRowMap(rows, func(row map[string]any) error {
+			count++
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if count != 10000 {
+			b.Fatalf("expected 10000 rows, got %d", count)
+		}
+	}
+}
+
 func BenchmarkSelectRowsPgConnExecText(b *testing.B) {
 	conn := mustConnectString(b, os.Getenv("PGX_TEST_DATABASE"))
 	defer closeConn(b, conn)
@@ -1289,3 +1355,42 @@ func BenchmarkSelectRowsRawPrepared(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkCollectRows(b *testing.B) {
+	conn := mustConnectString(b, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(b, conn)
+
+	ctx := context.Background()
+
+	for _, rowCount := range []int64{10, 1000, 100000} {
+		b.Run(fmt.Sprintf("%d rows", rowCount), func(b *testing.B) {
+			b.Run("CollectRows", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					rows, _ := conn.Query(ctx, "select n from generate_series(1, $1::int8) n", rowCount)
+					numbers, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+					if err != nil {
+						b.Fatal(err)
+					}
+					if int64(len(numbers)) != rowCount {
+						b.Fatalf("expected %d rows, got %d", rowCount, len(numbers))
+					}
+				}
+			})
+
+			b.Run("AppendRows with capacity", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					rows, _ := conn.Query(ctx, "select n from generate_series(1, $1::int8) n", rowCount)
+					numbers, err := pgx.AppendRows(make([]int64, 0, rowCount), rows, pgx.RowTo[int64])
+					if err != nil {
+						b.Fatal(err)
+					}
+					if int64(len(numbers)) != rowCount {
+						b.Fatalf("expected %d rows, got %d", rowCount, len(numbers))
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package pgx
+
+import (
+	"github.com/jackc/pgx/v5/internal/stmtcache"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// CacheEventKind is the kind of a CacheEvent.
+type CacheEventKind int
+
+const (
+	// CacheEventHit means the SQL was found in the cache.
+	CacheEventHit CacheEventKind = iota
+
+	// CacheEventMiss means the SQL was not found in the cache. It will be prepared or described and then added.
+	CacheEventMiss
+
+	// CacheEventEvict means the SQL was removed from the cache to make room for other SQL.
+	CacheEventEvict
+)
+
+func (k CacheEventKind) String() string {
+	switch k {
+	case CacheEventHit:
+		return "hit"
+	case CacheEventMiss:
+		return "miss"
+	case CacheEventEvict:
+		return "evict"
+	default:
+		return "invalid"
+	}
+}
+
+// CacheEvent is passed to ConnConfig.OnStatementCacheEvent.
+type CacheEvent struct {
+	Kind CacheEventKind
+
+	// SQL is the SQL text of the cache entry.
+	SQL string
+
+	// Mode is QueryExecModeCacheStatement for the statement cache or QueryExecModeCacheDescribe for the description
+	// cache.
+	Mode QueryExecMode
+}
+
+// newCache returns a statement description cache with capacity for the cache used by mode. The cache reports events to
+// c.config.OnStatementCacheEvent if it is set.
+func (c *Conn) newCache(capacity int, mode QueryExecMode) stmtcache.Cache {
+	cache := stmtcache.NewLRUCache(capacity)
+
+	onEvent := c.config.OnStatementCacheEvent
+	if onEvent == nil {
+		return cache
+	}
+
+	cache.SetEvictFunc(func(sd *pgconn.StatementDescription) {
+		onEvent(CacheEvent{Kind: CacheEventEvict, SQL: sd.SQL, Mode: mode})
+	})
+
+	return &eventCache{Cache: cache, onEvent: onEvent, mode: mode}
+}
+
+// eventCache reports hits and misses of Get.
+type eventCache struct {
+	stmtcache.Cache
+	onEvent func(CacheEvent)
+	mode    QueryExecMode
+}
+
+func (c *eventCache) Get(sql string) *pgconn.StatementDescription {
+	sd := c.Cache.Get(sql)
+	kind := CacheEventHit
+	if sd == nil {
+		kind = CacheEventMiss
+	}
+	c.onEvent(CacheEvent{Kind: kind, SQL: sql, Mode: c.mode})
+	return sd
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -41,6 +43,46 @@ type ConnConfig struct {
 	// functionality can be controlled on a per query basis by passing a QueryExecMode as the first query argument.
 	DefaultQueryExecMode QueryExecMode
 
+	// ForceISODateStyle causes the connection to set DateStyle to ISO immediately after connecting if the server reports
+	// a different DateStyle (e.g. German or SQL). The date order (e.g. DMY) reported by the server is preserved. Date and
+	// time values received in the text format are parsed assuming the ISO DateStyle. This includes all results when
+	// using QueryExecModeSimpleProtocol. It can be disabled if only the binary format is used and the server DateStyle
+	// must be left unchanged. It has no effect if DateStyle is explicitly set in RuntimeParams. Default: true.
+	ForceISODateStyle bool
+
+	// QueryRewriter, if set, rewrites the SQL and arguments of every query before it is sent to the server. It is applied
+	// after any QueryRewriter passed as a query argument such as NamedArgs. It is not applied when the SQL is the name of
+	// a statement prepared with Prepare. This can be used to add a comment such as a trace ID to each query. Note that
+	// SQL that differs on each execution will not benefit from the statement cache.
+	QueryRewriter QueryRewriter
+
+	// DisableBinaryFormat causes all query results to be requested in the text format and decoded with the text format
+	// of the registered types. This is a compatibility option for proxies or poolers that do not correctly handle binary
+	// results with the extended protocol. It applies to all queries on the connection, unlike SetResultFormatForOID
+	// which applies to a single type. A QueryResultFormats or QueryResultFormatsByOID query argument still takes
+	// precedence. Parameters are still sent in the format preferred by their type.
+	//
+	// The text format is usually larger on the wire and slower to parse than the binary format, especially for numeric,
+	// date and time, and array types. Enable it only when binary results cannot be used. Default: false.
+	DisableBinaryFormat bool
+
+	// DefaultQueryTimeout, if greater than zero, is sent as the statement_timeout run-time parameter in the startup
+	// message so no extra round trip is needed. Every statement on the connection is then bounded by the server, which
+	// cancels a statement that runs longer and returns a *pgconn.PgError with code 57014 (query_canceled). The timeout is
+	// rounded down to whole milliseconds, with a minimum of 1ms. If RuntimeParams already contains statement_timeout then
+	// that value is used and DefaultQueryTimeout is ignored.
+	//
+	// This is an ordinary session setting. It can be overridden for a single transaction with SET LOCAL
+	// statement_timeout or for the rest of the session with SET statement_timeout. RESET ALL and DISCARD ALL restore it
+	// to DefaultQueryTimeout. Default: 0 (no timeout is set).
+	DefaultQueryTimeout time.Duration
+
+	// OnStatementCacheEvent, if set, is called when a query looks up its SQL in the statement or description cache and
+	// when a cache evicts an entry because it is full. It can be used to collect hit, miss, and eviction metrics to size
+	// StatementCacheCapacity and DescriptionCacheCapacity. It is called synchronously on the goroutine using the
+	// connection and must not use the connection. When it is nil the caches are not instrumented.
+	OnStatementCacheEvent func(event CacheEvent)
+
 	createdByParseConfig bool // Used to enforce created by ParseConfig rule.
 }
 
@@ -63,7 +105,10 @@ func (cc *ConnConfig) Copy() *ConnConfig {
 func (cc *ConnConfig) ConnString() string { return cc.connString }
 
 // Conn is a PostgreSQL connection handle. It is not safe for concurrent usage. Use a connection pool to manage access
-// to multiple database connections from multiple goroutines.
+// to multiple database connections from multiple goroutines. Starting a query while another is still in progress (e.g.
+// before the previous Rows are closed) returns an error where errors.Is(err, ErrConnBusy) is true. This does not make
+// concurrent use safe. Conn state such as the statement cache is not synchronized, so using a Conn from multiple
+// goroutines at the same time is a data race even when it fails with ErrConnBusy.
 type Conn struct {
 	pgConn             *pgconn.PgConn
 	config             *ConnConfig // config used when establishing this connection
@@ -85,6 +130,11 @@ type Conn struct {
 
 	wbuf []byte
 	eqb  ExtendedQueryBuilder
+
+	createdAt  time.Time
+	lastUsedAt time.Time
+
+	connected bool // Set when connect has finished setting up the connection
 }
 
 // Identifier a PostgreSQL identifier or name. Identifiers can be composed of
@@ -106,6 +156,23 @@ var (
 	ErrNoRows = errors.New("no rows in result set")
 	// ErrTooManyRows occurs when more rows than expected are returned.
 	ErrTooManyRows = errors.New("too many rows in result set")
+	// ErrConnBusy occurs when an operation is started on a Conn while another operation is still in progress.
+	ErrConnBusy = pgconn.ErrConnBusy
+	// ErrConnClosed occurs when the connection is closed or is lost while a query is in progress. The Conn cannot be
+	// used again.
+	ErrConnClosed = pgconn.ErrConnClosed
+	// ErrUnsupportedAuthMethod occurs when connecting to a server that requests an authentication method pgx does not
+	// support (e.g. SSPI, or GSSAPI without a registered provider).
+	ErrUnsupportedAuthMethod = pgconn.ErrUnsupportedAuthMethod
+	// ErrMessageTooLarge occurs when the server sends a message larger than ConnConfig.MaxMessageSize. The connection
+	// is closed.
+	ErrMessageTooLarge = pgconn.ErrMessageTooLarge
+	// ErrUnsupportedParameterType occurs when a query argument has a Go type that cannot be encoded as the PostgreSQL
+	// type of its parameter. It is detected before anything is sent to the server.
+	ErrUnsupportedParameterType = errors.New("unsupported parameter type")
+	// ErrWrongNumberOfArguments occurs when the number of query arguments does not match the number of parameters of
+	// the statement. It is detected before anything is sent to the server.
+	ErrWrongNumberOfArguments = errors.New("wrong number of arguments")
 )
 
 var errDisabledStatementCache = fmt.Errorf("cannot use QueryExecModeCacheStatement with disabled statement cache")
@@ -188,12 +255,23 @@ func ParseConfigWithOptions(connString string, options ParseConfigOptions) (*Con
 		}
 	}
 
+	disableBinaryFormat := false
+	if s, ok := config.RuntimeParams["disable_binary_format"]; ok {
+		delete(config.RuntimeParams, "disable_binary_format")
+		disableBinaryFormat, err = strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse disable_binary_format: %w", err)
+		}
+	}
+
 	connConfig := &ConnConfig{
 		Config:                   *config,
 		createdByParseConfig:     true,
 		StatementCacheCapacity:   statementCacheCapacity,
 		DescriptionCacheCapacity: descriptionCacheCapacity,
 		DefaultQueryExecMode:     defaultQueryExecMode,
+		ForceISODateStyle:        true,
+		DisableBinaryFormat:      disableBinaryFormat,
 		connString:               connString,
 	}
 
@@ -214,6 +292,9 @@ func ParseConfigWithOptions(connString string, options ParseConfigOptions) (*Con
 //   - description_cache_capacity.
 //     The maximum size of the description cache used when executing a query with "cache_describe" query exec mode.
 //     Default: 512.
+//
+//   - disable_binary_format.
+//     Request all query results in the text format. See ConnConfig.DisableBinaryFormat. Default: false.
 func ParseConfig(connString string) (*ConnConfig, error) {
 	return ParseConfigWithOptions(connString, ParseConfigOptions{})
 }
@@ -254,27 +335,98 @@ func connect(ctx context.Context, config *ConnConfig) (c *Conn, err error) {
 		config.Config.OnNotification = c.bufferNotifications
 	}
 
+	// Parameter changes caused by pgx setting up the connection are not reported.
+	if onParameterStatus := config.Config.OnParameterStatus; onParameterStatus != nil {
+		config.Config.OnParameterStatus = func(pgConn *pgconn.PgConn, name, value string) {
+			if c.connected {
+				onParameterStatus(pgConn, name, value)
+			}
+		}
+	}
+
+	if config.DefaultQueryTimeout > 0 && !hasRuntimeParam(config.RuntimeParams, "statement_timeout") {
+		if config.RuntimeParams == nil {
+			config.RuntimeParams = make(map[string]string)
+		}
+		config.RuntimeParams["statement_timeout"] = statementTimeoutParam(config.DefaultQueryTimeout)
+	}
+
 	c.pgConn, err = pgconn.ConnectConfig(ctx, &config.Config)
 	if err != nil {
 		return nil, err
 	}
+	c.createdAt = time.Now()
+	c.lastUsedAt = c.createdAt
 
+	if config.ForceISODateStyle && !hasRuntimeParam(config.RuntimeParams, "DateStyle") {
+		err = forceISODateStyle(ctx, c.pgConn)
+		if err != nil {
+			c.pgConn.Close(ctx)
+			return nil, err
+		}
+	}
+
+	c.eqb.textResultsOnly = config.DisableBinaryFormat
+	// Servers built with floating point datetimes (only possible before PostgreSQL 10) use float8 seconds instead of
+	// int64 microseconds in the binary format of time, timestamp, and interval types.
+	c.typeMap.SetFloatDatetimes(c.pgConn.ParameterStatus("integer_datetimes") == "off")
 	c.preparedStatements = make(map[string]*pgconn.StatementDescription)
 	c.doneChan = make(chan struct{})
 	c.closedChan = make(chan error)
 	c.wbuf = make([]byte, 0, 1024)
 
 	if c.config.StatementCacheCapacity > 0 {
-		c.statementCache = stmtcache.NewLRUCache(c.config.StatementCacheCapacity)
+		c.statementCache = c.newCache(c.config.StatementCacheCapacity, QueryExecModeCacheStatement)
 	}
 
 	if c.config.DescriptionCacheCapacity > 0 {
-		c.descriptionCache = stmtcache.NewLRUCache(c.config.DescriptionCacheCapacity)
+		c.descriptionCache = c.newCache(c.config.DescriptionCacheCapacity, QueryExecModeCacheDescribe)
 	}
 
+	c.connected = true
+
 	return c, nil
 }
 
+// hasRuntimeParam reports whether name is set in runtimeParams. Server parameter names are case-insensitive.
+func hasRuntimeParam(runtimeParams map[string]string, name string) bool {
+	for k := range runtimeParams {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// forceISODateStyle sets DateStyle to ISO if the server reports any other output format.
+func forceISODateStyle(ctx context.Context, pgConn *pgconn.PgConn) error {
+	dateStyle := pgConn.ParameterStatus("DateStyle")
+	if dateStyle == "" || strings.HasPrefix(dateStyle, "ISO") {
+		return nil
+	}
+
+	newDateStyle := "ISO"
+	if _, order, found := strings.Cut(dateStyle, ","); found {
+		switch order = strings.TrimSpace(order); order {
+		case "MDY", "DMY", "YMD":
+			newDateStyle += ", " + order
+		}
+	}
+
+	_, err := pgConn.Exec(ctx, "set DateStyle = '"+newDateStyle+"'").ReadAll()
+	return err
+}
+
+// statementTimeoutParam returns d rounded down to whole milliseconds as a statement_timeout value.
+func statementTimeoutParam(d time.Duration) string {
+	ms := d.Milliseconds()
+	if ms < 1 {
+		ms = 1
+	}
+
+	return strconv.FormatInt(ms, 10)
+}
+
 // Close closes a connection. It is safe to call Close on an already closed
 // connection.
 func (c *Conn) Close(ctx context.Context) error {
@@ -291,18 +443,35 @@ func (c *Conn) Close(ctx context.Context) error {
 // Exec to execute the statement. It can also be used with Batch.Queue.
 //
 // The underlying PostgreSQL identifier for the prepared statement will be name if name != sql or a digest of sql if
-// name == sql.
+// name == sql. Passing sql as name is a convenient way to prepare statements keyed by their SQL text without managing
+// names.
 //
 // Prepare is idempotent; i.e. it is safe to call Prepare multiple times with the same name and sql arguments. This
-// allows a code path to Prepare and Query/Exec without concern for if the statement has already been prepared.
+// allows a code path to Prepare and Query/Exec without concern for if the statement has already been prepared. Calling
+// Prepare with the name of an existing prepared statement but different sql returns an error without contacting the
+// server.
 func (c *Conn) Prepare(ctx context.Context, name, sql string) (sd *pgconn.StatementDescription, err error) {
+	return c.prepare(ctx, name, sql, nil)
+}
+
+// PrepareWithParamOIDs is like Prepare but explicitly specifies the types of the parameters. This is useful when
+// PostgreSQL cannot infer the type of a parameter (e.g. "could not determine data type of parameter $1"). paramOIDs
+// may be shorter than the number of parameters. A zero OID leaves the type of that parameter to be inferred by the
+// server.
+func (c *Conn) PrepareWithParamOIDs(ctx context.Context, name, sql string, paramOIDs []uint32) (sd *pgconn.StatementDescription, err error) {
+	return c.prepare(ctx, name, sql, paramOIDs)
+}
+
+func (c *Conn) prepare(ctx context.Context, name, sql string, paramOIDs []uint32) (sd *pgconn.StatementDescription, err error) {
+	c.lastUsedAt = time.Now()
+
 	if c.prepareTracer != nil {
 		ctx = c.prepareTracer.TracePrepareStart(ctx, c, TracePrepareStartData{Name: name, SQL: sql})
 	}
 
+	var alreadyPrepared bool
 	if name != "" {
-		var ok bool
-		if sd, ok = c.preparedStatements[name]; ok && sd.SQL == sql {
+		if sd, alreadyPrepared = c.preparedStatements[name]; alreadyPrepared && sd.SQL == sql && paramOIDsMatch(sd.ParamOIDs, paramOIDs) {
 			if c.prepareTracer != nil {
 				c.prepareTracer.TracePrepareEnd(ctx, c, TracePrepareEndData{AlreadyPrepared: true})
 			}
@@ -316,49 +485,196 @@ func (c *Conn) Prepare(ctx context.Context, name, sql string) (sd *pgconn.Statem
 		}()
 	}
 
-	var psName, psKey string
-	if name == sql {
-		digest := sha256.Sum256([]byte(sql))
-		psName = "stmt_" + hex.EncodeToString(digest[0:24])
-		psKey = sql
-	} else {
-		psName = name
-		psKey = name
+	if alreadyPrepared {
+		return nil, fmt.Errorf("prepared statement %q already exists with different SQL or parameter types", name)
 	}
 
-	sd, err = c.pgConn.Prepare(ctx, psName, sql, nil)
+	psName := preparedStatementName(name, sql)
+	sd, err = c.pgConn.Prepare(ctx, psName, sql, paramOIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	if psKey != "" {
-		c.preparedStatements[psKey] = sd
+	if name != "" {
+		c.preparedStatements[name] = sd
 	}
 
 	return sd, nil
 }
 
-// Deallocate releases a prepared statement.
+// preparedStatementName returns the server side name of the statement Prepare was called with name and sql.
+func preparedStatementName(name, sql string) string {
+	if name == sql {
+		digest := sha256.Sum256([]byte(sql))
+		return "stmt_" + hex.EncodeToString(digest[0:24])
+	}
+	return name
+}
+
+// PrepareBatch prepares each statement in stmts in a single network round trip. stmts maps statement names to sql. This
+// is significantly faster than calling Prepare for each statement when there are many statements to prepare such as in
+// an AfterConnect hook. Statement names follow the same rules as Prepare.
+//
+// A statement that is already prepared with the same sql is skipped. If a name is already prepared with different sql
+// an error is returned without contacting the server. If any statement fails to prepare the error includes its name and
+// none of the statements in stmts are left prepared.
+//
+// Each statement is traced by the PrepareTracer as if it was prepared with Prepare. When the batch fails every
+// statement that was not already prepared ends with the returned error.
+func (c *Conn) PrepareBatch(ctx context.Context, stmts map[string]string) (err error) {
+	c.lastUsedAt = time.Now()
+
+	allNames := make([]string, 0, len(stmts))
+	for name := range stmts {
+		allNames = append(allNames, name)
+	}
+	sort.Strings(allNames)
+
+	var traceCtxs []context.Context
+	if c.prepareTracer != nil {
+		defer func() {
+			for _, traceCtx := range traceCtxs {
+				c.prepareTracer.TracePrepareEnd(traceCtx, c, TracePrepareEndData{Err: err})
+			}
+		}()
+	}
+
+	names := make([]string, 0, len(stmts))
+	for _, name := range allNames {
+		sql := stmts[name]
+		traceCtx := ctx
+		if c.prepareTracer != nil {
+			traceCtx = c.prepareTracer.TracePrepareStart(ctx, c, TracePrepareStartData{Name: name, SQL: sql})
+		}
+
+		sd, alreadyPrepared := c.preparedStatements[name]
+		if alreadyPrepared && sd.SQL == sql {
+			if c.prepareTracer != nil {
+				c.prepareTracer.TracePrepareEnd(traceCtx, c, TracePrepareEndData{AlreadyPrepared: true})
+			}
+			continue
+		}
+
+		if c.prepareTracer != nil {
+			traceCtxs = append(traceCtxs, traceCtx)
+		}
+		if alreadyPrepared {
+			return fmt.Errorf("prepared statement %q already exists with different SQL or parameter types", name)
+		}
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	pipeline := c.pgConn.StartPipeline(ctx)
+	for _, name := range names {
+		pipeline.SendPrepare(preparedStatementName(name, stmts[name]), stmts[name], nil)
+	}
+
+	err = pipeline.Sync()
+	if err != nil {
+		return err
+	}
+
+	sds := make([]*pgconn.StatementDescription, 0, len(names))
+	for _, name := range names {
+		results, err := pipeline.GetResults()
+		if err == nil {
+			sd, ok := results.(*pgconn.StatementDescription)
+			if !ok {
+				err = fmt.Errorf("expected statement description, got %T", results)
+			} else {
+				sd.Name = preparedStatementName(name, stmts[name])
+				sd.SQL = stmts[name]
+				sds = append(sds, sd)
+				continue
+			}
+		}
+
+		// The server skips the remaining statements after an error. Deallocate the statements that were prepared so the
+		// server and the client agree on which statements exist.
+		for _, sd := range sds {
+			pipeline.SendDeallocate(sd.Name)
+		}
+		if len(sds) > 0 {
+			pipeline.Sync()
+		}
+		pipeline.Close()
+
+		return fmt.Errorf("failed to prepare statement %q: %w", name, err)
+	}
+
+	err = pipeline.Close()
+	if err != nil {
+		return err
+	}
+
+	for i, name := range names {
+		if name != "" {
+			c.preparedStatements[name] = sds[i]
+		}
+	}
+
+	return nil
+}
+
+// paramOIDsMatch returns true if every non-zero OID in requested matches the corresponding OID in actual.
+func paramOIDsMatch(actual, requested []uint32) bool {
+	if len(requested) > len(actual) {
+		return false
+	}
+
+	for i, oid := range requested {
+		if oid != 0 && oid != actual[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Deallocate releases a prepared statement on the server and stops tracking it so name can be prepared again. If the
+// server fails to deallocate the statement, e.g. because the current transaction is aborted, the statement is still
+// tracked and remains usable.
 func (c *Conn) Deallocate(ctx context.Context, name string) error {
-	var psName string
-	if sd, ok := c.preparedStatements[name]; ok {
-		delete(c.preparedStatements, name)
+	psName := name
+	sd, ok := c.preparedStatements[name]
+	if ok {
 		psName = sd.Name
-	} else {
-		psName = name
 	}
 	_, err := c.pgConn.Exec(ctx, "deallocate "+quoteIdentifier(psName)).ReadAll()
-	return err
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		delete(c.preparedStatements, name)
+	}
+	return nil
+}
+
+// PreparedStatements returns the statements currently prepared on the connection sorted by name. This includes
+// statements prepared with Prepare and statements prepared automatically by the statement cache. The returned statement
+// descriptions are shared with the connection and must not be modified.
+func (c *Conn) PreparedStatements() []*pgconn.StatementDescription {
+	sds := make([]*pgconn.StatementDescription, 0, len(c.preparedStatements))
+	for _, sd := range c.preparedStatements {
+		sds = append(sds, sd)
+	}
+	sort.Slice(sds, func(i, j int) bool { return sds[i].Name < sds[j].Name })
+	return sds
 }
 
 // DeallocateAll releases all previously prepared statements from the server and client, where it also resets the statement and description cache.
 func (c *Conn) DeallocateAll(ctx context.Context) error {
 	c.preparedStatements = map[string]*pgconn.StatementDescription{}
 	if c.config.StatementCacheCapacity > 0 {
-		c.statementCache = stmtcache.NewLRUCache(c.config.StatementCacheCapacity)
+		c.statementCache = c.newCache(c.config.StatementCacheCapacity, QueryExecModeCacheStatement)
 	}
 	if c.config.DescriptionCacheCapacity > 0 {
-		c.descriptionCache = stmtcache.NewLRUCache(c.config.DescriptionCacheCapacity)
+		c.descriptionCache = c.newCache(c.config.DescriptionCacheCapacity, QueryExecModeCacheDescribe)
 	}
 	_, err := c.pgConn.Exec(ctx, "deallocate all").ReadAll()
 	return err
@@ -393,6 +709,17 @@ func (c *Conn) IsClosed() bool {
 	return c.pgConn.IsClosed()
 }
 
+// CreatedAt returns the time the connection was established.
+func (c *Conn) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// LastUsedAt returns the time the connection last started a query, batch, copy, or prepare. It is the same as CreatedAt
+// if the connection has not been used.
+func (c *Conn) LastUsedAt() time.Time {
+	return c.lastUsedAt
+}
+
 func (c *Conn) die(err error) {
 	if c.IsClosed() {
 		return
@@ -422,12 +749,31 @@ func (c *Conn) PgConn() *pgconn.PgConn { return c.pgConn }
 // TypeMap returns the connection info used for this connection.
 func (c *Conn) TypeMap() *pgtype.Map { return c.typeMap }
 
+// SetResultFormatForOID sets the result format (pgtype.TextFormatCode or pgtype.BinaryFormatCode) requested for result
+// columns of type oid. This overrides the format the type map would otherwise choose, e.g. to receive numeric values in
+// text format. It does not apply to QueryExecModeExec or QueryExecModeSimpleProtocol which always receive text, and
+// an explicit QueryResultFormats or QueryResultFormatsByOID argument takes precedence.
+func (c *Conn) SetResultFormatForOID(oid uint32, format int16) {
+	if c.eqb.resultFormatsByOID == nil {
+		c.eqb.resultFormatsByOID = make(map[uint32]int16)
+	}
+	c.eqb.resultFormatsByOID[oid] = format
+}
+
+// ResetResultFormatForOID removes a result format override set with SetResultFormatForOID.
+func (c *Conn) ResetResultFormatForOID(oid uint32) {
+	delete(c.eqb.resultFormatsByOID, oid)
+}
+
 // Config returns a copy of config that was used to establish this connection.
 func (c *Conn) Config() *ConnConfig { return c.config.Copy() }
 
 // Exec executes sql. sql can be either a prepared statement name or an SQL string. arguments should be referenced
-// positionally from the sql string as $1, $2, etc.
+// positionally from the sql string as $1, $2, etc. Any rows returned by sql, such as from an INSERT ... RETURNING, are
+// read and discarded. The returned CommandTag reports the number of rows affected.
 func (c *Conn) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	c.lastUsedAt = time.Now()
+
 	if c.queryTracer != nil {
 		ctx = c.queryTracer.TraceQueryStart(ctx, c, TraceQueryStartData{SQL: sql, Args: arguments})
 	}
@@ -445,6 +791,56 @@ func (c *Conn) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.C
 	return commandTag, err
 }
 
+// ExecuteScript executes sql, which may contain multiple semicolon separated statements, with the simple protocol and
+// discards any results. This is intended for running setup or migration scripts. Execution stops at the first statement
+// that fails. If that error was reported by the server it is wrapped with the 1-based position of the failing statement
+// in the script. The connection remains usable after a server error.
+//
+// The server runs a multi-statement simple query in a single implicit transaction. Unless the script contains explicit
+// transaction control statements, an error rolls back the effects of all earlier statements in the script.
+//
+// ConnConfig.QueryRewriter is applied to the whole script.
+func (c *Conn) ExecuteScript(ctx context.Context, sql string) error {
+	c.lastUsedAt = time.Now()
+
+	if c.queryTracer != nil {
+		ctx = c.queryTracer.TraceQueryStart(ctx, c, TraceQueryStartData{SQL: sql})
+	}
+
+	if err := c.deallocateInvalidatedCachedStatements(ctx); err != nil {
+		return err
+	}
+
+	err := c.executeScript(ctx, sql)
+
+	if c.queryTracer != nil {
+		c.queryTracer.TraceQueryEnd(ctx, c, TraceQueryEndData{Err: err})
+	}
+
+	return err
+}
+
+func (c *Conn) executeScript(ctx context.Context, sql string) error {
+	sql, _, err := c.rewriteQuery(ctx, nil, sql, nil)
+	if err != nil {
+		return fmt.Errorf("rewrite query failed: %v", err)
+	}
+
+	results, err := c.pgConn.Exec(ctx, sql).ReadAll()
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		statementNum := 1
+		for _, r := range results {
+			if r.Err == nil {
+				statementNum++
+			}
+		}
+		err = fmt.Errorf("script statement %d failed: %w", statementNum, err)
+	}
+
+	return err
+}
+
 func (c *Conn) exec(ctx context.Context, sql string, arguments ...any) (commandTag pgconn.CommandTag, err error) {
 	mode := c.config.DefaultQueryExecMode
 	var queryRewriter QueryRewriter
@@ -463,11 +859,9 @@ optionLoop:
 		}
 	}
 
-	if queryRewriter != nil {
-		sql, arguments, err = queryRewriter.RewriteQuery(ctx, c, sql, arguments)
-		if err != nil {
-			return pgconn.CommandTag{}, fmt.Errorf("rewrite query failed: %v", err)
-		}
+	sql, arguments, err = c.rewriteQuery(ctx, queryRewriter, sql, arguments)
+	if err != nil {
+		return pgconn.CommandTag{}, fmt.Errorf("rewrite query failed: %v", err)
 	}
 
 	// Always use simple protocol when there are no arguments.
@@ -568,6 +962,10 @@ func (e *unknownArgumentTypeQueryExecModeExecError) Error() string {
 	return fmt.Sprintf("cannot use unregistered type %T as query argument in QueryExecModeExec", e.arg)
 }
 
+func (e *unknownArgumentTypeQueryExecModeExecError) Unwrap() error {
+	return ErrUnsupportedParameterType
+}
+
 func (c *Conn) execSQLParams(ctx context.Context, sql string, args []any) (pgconn.CommandTag, error) {
 	err := c.eqb.Build(c.typeMap, nil, args)
 	if err != nil {
@@ -668,6 +1066,28 @@ type QueryRewriter interface {
 	RewriteQuery(ctx context.Context, conn *Conn, sql string, args []any) (newSQL string, newArgs []any, err error)
 }
 
+// rewriteQuery applies queryRewriter, if not nil, and then the ConnConfig.QueryRewriter to sql and args.
+func (c *Conn) rewriteQuery(ctx context.Context, queryRewriter QueryRewriter, sql string, args []any) (string, []any, error) {
+	var err error
+	if queryRewriter != nil {
+		sql, args, err = queryRewriter.RewriteQuery(ctx, c, sql, args)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	if c.config.QueryRewriter != nil {
+		if _, ok := c.preparedStatements[sql]; !ok {
+			sql, args, err = c.config.QueryRewriter.RewriteQuery(ctx, c, sql, args)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	return sql, args, nil
+}
+
 // Query sends a query to the server and returns a Rows to read the results. Only errors encountered sending the query
 // and initializing Rows will be returned. Err() on the returned Rows must be checked after the Rows is closed to
 // determine if the query executed successfully.
@@ -690,6 +1110,98 @@ type QueryRewriter interface {
 // QueryResultFormatsByOID may be used as the first args to control exactly how the query is executed. This is rarely
 // needed. See the documentation for those types for details.
 func (c *Conn) Query(ctx context.Context, sql string, args ...any) (Rows, error) {
+	return c.query(ctx, sql, 0, args)
+}
+
+// QueryLimited is like Query but the server returns at most maxRows rows. If the query has more rows the unnamed
+// portal is suspended and rows.CommandTag() is empty after the returned Rows is closed. The next rows can be fetched
+// with FetchPortal with an empty portal name. When the last rows have been returned rows.CommandTag() is the command
+// tag of the statement.
+//
+// This allows paging through a large result without declaring a cursor. However, the portal only remains open inside
+// of a transaction. Outside of a transaction QueryLimited is equivalent to adding a LIMIT to the query. Running any
+// other query with the extended protocol replaces the unnamed portal.
+//
+// QueryLimited requires the extended protocol. It returns an error if QueryExecModeSimpleProtocol is used.
+func (c *Conn) QueryLimited(ctx context.Context, sql string, maxRows int, args ...any) (Rows, error) {
+	if maxRows < 1 || int64(maxRows) > math.MaxUint32 {
+		err := fmt.Errorf("maxRows must be between 1 and %d, got %d", uint32(math.MaxUint32), maxRows)
+		return &baseRows{err: err, closed: true}, err
+	}
+
+	return c.query(ctx, sql, uint32(maxRows), args)
+}
+
+// FetchPortal fetches at most maxRows more rows from the portal portalName. The unnamed portal used by QueryLimited
+// is identified by the empty string. If maxRows is 0 all remaining rows are returned. As with QueryLimited,
+// rows.CommandTag() is empty after the returned Rows is closed if the portal was suspended again.
+//
+// Fetching from a portal that does not exist, such as one that was closed at the end of a transaction, is an error.
+func (c *Conn) FetchPortal(ctx context.Context, portalName string, maxRows int) (Rows, error) {
+	c.lastUsedAt = time.Now()
+
+	if maxRows < 0 || int64(maxRows) > math.MaxUint32 {
+		err := fmt.Errorf("maxRows must be between 0 and %d, got %d", uint32(math.MaxUint32), maxRows)
+		return &baseRows{err: err, closed: true}, err
+	}
+
+	if c.queryTracer != nil {
+		ctx = c.queryTracer.TraceQueryStart(ctx, c, TraceQueryStartData{})
+	}
+
+	rows := c.getRows(ctx, "", nil)
+	rows.resultReader = c.pgConn.ExecPortal(ctx, portalName, uint32(maxRows))
+
+	return rows, rows.err
+}
+
+// QueryPortal executes the prepared statement stmtName with args in the portal portalName and returns at most maxRows
+// rows. If maxRows is 0 all rows are returned. stmtName must be the name of a statement prepared with Prepare. If
+// portalName is empty the unnamed portal is used.
+//
+// Unlike Query, which always uses the unnamed portal, QueryPortal can keep several portals open on one connection. The
+// next rows from each portal are fetched with FetchPortal. Portals are closed at the end of the transaction so
+// QueryPortal is only useful inside of a transaction. A named portal can not be reused until it is closed, e.g. with
+// the SQL command CLOSE. See pgconn.PgConn.ExecPreparedPortal for details.
+func (c *Conn) QueryPortal(ctx context.Context, portalName, stmtName string, maxRows int, args ...any) (Rows, error) {
+	c.lastUsedAt = time.Now()
+
+	if c.queryTracer != nil {
+		ctx = c.queryTracer.TraceQueryStart(ctx, c, TraceQueryStartData{SQL: stmtName, Args: args})
+	}
+
+	anynil.NormalizeSlice(args)
+	rows := c.getRows(ctx, stmtName, args)
+
+	if maxRows < 0 || int64(maxRows) > math.MaxUint32 {
+		rows.fatal(fmt.Errorf("maxRows must be between 0 and %d, got %d", uint32(math.MaxUint32), maxRows))
+		return rows, rows.err
+	}
+
+	sd, ok := c.preparedStatements[stmtName]
+	if !ok {
+		rows.fatal(fmt.Errorf("prepared statement %q does not exist", stmtName))
+		return rows, rows.err
+	}
+
+	rows.sql = sd.SQL
+
+	err := c.eqb.Build(c.typeMap, sd, args)
+	if err != nil {
+		rows.fatal(err)
+		return rows, rows.err
+	}
+
+	rows.resultReader = c.pgConn.ExecPreparedPortal(ctx, portalName, sd.Name, c.eqb.ParamValues, c.eqb.ParamFormats, c.eqb.ResultFormats, uint32(maxRows))
+
+	c.eqb.reset() // Allow c.eqb internal memory to be GC'ed as soon as possible.
+
+	return rows, rows.err
+}
+
+func (c *Conn) query(ctx context.Context, sql string, maxRows uint32, args []any) (Rows, error) {
+	c.lastUsedAt = time.Now()
+
 	if c.queryTracer != nil {
 		ctx = c.queryTracer.TraceQueryStart(ctx, c, TraceQueryStartData{SQL: sql, Args: args})
 	}
@@ -726,11 +1238,11 @@ optionLoop:
 		}
 	}
 
-	if queryRewriter != nil {
+	if queryRewriter != nil || c.config.QueryRewriter != nil {
 		var err error
 		originalSQL := sql
 		originalArgs := args
-		sql, args, err = queryRewriter.RewriteQuery(ctx, c, sql, args)
+		sql, args, err = c.rewriteQuery(ctx, queryRewriter, sql, args)
 		if err != nil {
 			rows := c.getRows(ctx, originalSQL, originalArgs)
 			err = fmt.Errorf("rewrite query failed: %v", err)
@@ -760,7 +1272,7 @@ optionLoop:
 		}
 
 		if len(sd.ParamOIDs) != len(args) {
-			rows.fatal(fmt.Errorf("expected %d arguments, got %d", len(sd.ParamOIDs), len(args)))
+			rows.fatal(fmt.Errorf("%w: expected %d, got %d", ErrWrongNumberOfArguments, len(sd.ParamOIDs), len(args)))
 			return rows, rows.err
 		}
 
@@ -784,9 +1296,9 @@ optionLoop:
 		}
 
 		if !explicitPreparedStatement && mode == QueryExecModeCacheDescribe {
-			rows.resultReader = c.pgConn.ExecParams(ctx, sql, c.eqb.ParamValues, sd.ParamOIDs, c.eqb.ParamFormats, resultFormats)
+			rows.resultReader = c.pgConn.ExecParamsLimit(ctx, sql, c.eqb.ParamValues, sd.ParamOIDs, c.eqb.ParamFormats, resultFormats, maxRows)
 		} else {
-			rows.resultReader = c.pgConn.ExecPrepared(ctx, sd.Name, c.eqb.ParamValues, c.eqb.ParamFormats, resultFormats)
+			rows.resultReader = c.pgConn.ExecPreparedLimit(ctx, sd.Name, c.eqb.ParamValues, c.eqb.ParamFormats, resultFormats, maxRows)
 		}
 	} else if mode == QueryExecModeExec {
 		err := c.eqb.Build(c.typeMap, nil, args)
@@ -795,8 +1307,14 @@ optionLoop:
 			return rows, rows.err
 		}
 
-		rows.resultReader = c.pgConn.ExecParams(ctx, sql, c.eqb.ParamValues, nil, c.eqb.ParamFormats, c.eqb.ResultFormats)
+		rows.resultReader = c.pgConn.ExecParamsLimit(ctx, sql, c.eqb.ParamValues, nil, c.eqb.ParamFormats, c.eqb.ResultFormats, maxRows)
 	} else if mode == QueryExecModeSimpleProtocol {
+		if maxRows > 0 {
+			err = errors.New("row limit is not supported with the simple protocol")
+			rows.fatal(err)
+			return rows, err
+		}
+
 		sql, err = c.sanitizeForSimpleQuery(sql, args...)
 		if err != nil {
 			rows.fatal(err)
@@ -825,6 +1343,84 @@ optionLoop:
 	return rows, rows.err
 }
 
+// SimpleQuery sends sql to the server with the simple query protocol in a single round trip. It is intended for one-off
+// queries where the Parse, Describe, and Bind steps of the extended protocol would only add latency. SimpleQuery does not
+// accept arguments; any values must already be safely inlined in sql. Use SimpleQueryArgs to have arguments inlined.
+// Results are always received in the text format and decoded with the text format of the registered types. Prepared
+// statements and the statement cache are not used. If sql contains multiple statements only the results of the first
+// are returned.
+func (c *Conn) SimpleQuery(ctx context.Context, sql string) (Rows, error) {
+	return c.SimpleQueryArgs(ctx, sql)
+}
+
+// SimpleQueryArgs is like SimpleQuery but args are interpolated into sql on the client before it is sent. Arguments are
+// referenced positionally from the sql string as $1, $2, etc. Placeholders inside string literals, quoted identifiers,
+// and comments are ignored.
+//
+// Each argument is formatted as follows:
+//
+//   - nil, a nil pointer, or a value whose Value or encoder reports NULL is written as NULL.
+//   - Any other value is encoded in the PostgreSQL text format with the connection's type map and written as a quoted
+//     string literal with any embedded single quotes doubled. e.g. 42 becomes '42', "foo" becomes 'foo', and
+//     []byte{1, 2} becomes '\x0102'.
+//
+// Numbers are quoted rather than written bare so that a negative number can never combine with a preceding minus sign
+// into a comment. PostgreSQL converts the quoted literal to the type required by the surrounding expression.
+//
+// Interpolation requires standard_conforming_strings to be on and client_encoding to be UTF8. It is the same
+// interpolation used by QueryExecModeSimpleProtocol.
+func (c *Conn) SimpleQueryArgs(ctx context.Context, sql string, args ...any) (Rows, error) {
+	c.lastUsedAt = time.Now()
+
+	if c.queryTracer != nil {
+		ctx = c.queryTracer.TraceQueryStart(ctx, c, TraceQueryStartData{SQL: sql, Args: args})
+	}
+
+	if err := c.deallocateInvalidatedCachedStatements(ctx); err != nil {
+		if c.queryTracer != nil {
+			c.queryTracer.TraceQueryEnd(ctx, c, TraceQueryEndData{Err: err})
+		}
+		return &baseRows{err: err, closed: true}, err
+	}
+
+	if c.config.QueryRewriter != nil {
+		var err error
+		originalSQL := sql
+		originalArgs := args
+		sql, args, err = c.rewriteQuery(ctx, nil, sql, args)
+		if err != nil {
+			rows := c.getRows(ctx, originalSQL, originalArgs)
+			err = fmt.Errorf("rewrite query failed: %v", err)
+			rows.fatal(err)
+			return rows, err
+		}
+	}
+
+	anynil.NormalizeSlice(args)
+	rows := c.getRows(ctx, sql, args)
+
+	if len(args) > 0 {
+		var err error
+		sql, err = c.sanitizeForSimpleQuery(sql, args...)
+		if err != nil {
+			rows.fatal(err)
+			return rows, err
+		}
+	}
+
+	mrr := c.pgConn.Exec(ctx, sql)
+	if mrr.NextResult() {
+		rows.resultReader = mrr.ResultReader()
+		rows.multiResultReader = mrr
+	} else {
+		err := mrr.Close()
+		rows.fatal(err)
+		return rows, err
+	}
+
+	return rows, nil
+}
+
 // getStatementDescription returns the statement description of the sql query
 // according to the given mode.
 //
@@ -870,6 +1466,13 @@ func (c *Conn) getStatementDescription(
 // QueryRow is a convenience wrapper over Query. Any error that occurs while
 // querying is deferred until calling Scan on the returned Row. That Row will
 // error with ErrNoRows if no rows are returned.
+//
+// Scan must be called on the returned Row. It scans the first row, discards any
+// others, and closes the underlying Rows so the connection can be used again.
+// e.g.
+//
+//	var name string
+//	err := conn.QueryRow(ctx, "select name from users where id=$1", id).Scan(&name)
 func (c *Conn) QueryRow(ctx context.Context, sql string, args ...any) Row {
 	rows, _ := c.Query(ctx, sql, args...)
 	return (*connRow)(rows.(*baseRows))
@@ -879,6 +1482,8 @@ func (c *Conn) QueryRow(ctx context.Context, sql string, args ...any) Row {
 // explicit transaction control statements are executed. The returned BatchResults must be closed before the connection
 // is used again.
 func (c *Conn) SendBatch(ctx context.Context, b *Batch) (br BatchResults) {
+	c.lastUsedAt = time.Now()
+
 	if c.batchTracer != nil {
 		ctx = c.batchTracer.TraceBatchStart(ctx, c, TraceBatchStartData{Batch: b})
 		defer func() {
@@ -911,12 +1516,10 @@ func (c *Conn) SendBatch(ctx context.Context, b *Batch) (br BatchResults) {
 			}
 		}
 
-		if queryRewriter != nil {
-			var err error
-			sql, arguments, err = queryRewriter.RewriteQuery(ctx, c, sql, arguments)
-			if err != nil {
-				return &batchResults{ctx: ctx, conn: c, err: fmt.Errorf("rewrite query failed: %v", err)}
-			}
+		var err error
+		sql, arguments, err = c.rewriteQuery(ctx, queryRewriter, sql, arguments)
+		if err != nil {
+			return &batchResults{ctx: ctx, conn: c, err: fmt.Errorf("rewrite query failed: %v", err)}
 		}
 
 		bi.query = sql
@@ -1139,6 +1742,11 @@ func (c *Conn) sendBatchExtendedWithDescription(ctx context.Context, b *Batch, d
 	if sdCache != nil {
 		for _, sd := range distinctNewQueries {
 			sdCache.Put(sd)
+
+			// Named statements were prepared on the server. Track them the same as Prepare does.
+			if sd.Name != "" {
+				c.preparedStatements[sd.Name] = sd
+			}
 		}
 	}
 
@@ -1185,6 +1793,9 @@ func (c *Conn) sanitizeForSimpleQuery(sql string, args ...any) (string, error) {
 	for i, a := range args {
 		valueArgs[i], err = convertSimpleArgument(c.typeMap, a)
 		if err != nil {
+			if c.typeMap.PlanEncode(0, TextFormatCode, a) == nil {
+				return "", fmt.Errorf("failed to encode args[%d]: %w: cannot encode %T", i, ErrUnsupportedParameterType, a)
+			}
 			return "", err
 		}
 	}
@@ -1203,19 +1814,28 @@ func (c *Conn) LoadType(ctx context.Context, typeName string) (*pgtype.Type, err
 
 	var typtype string
 	var typbasetype uint32
+	var typcategory string
 
-	err = c.QueryRow(ctx, "select typtype::text, typbasetype from pg_type where oid=$1", oid).Scan(&typtype, &typbasetype)
+	err = c.QueryRow(ctx, "select typtype::text, typbasetype, typcategory::text from pg_type where oid=$1", oid).Scan(&typtype, &typbasetype, &typcategory)
 	if err != nil {
 		return nil, err
 	}
 
 	switch typtype {
-	case "b": // array
+	case "b": // array or base type
 		elementOID, err := c.getArrayElementOID(ctx, oid)
 		if err != nil {
 			return nil, err
 		}
 
+		if elementOID == 0 {
+			// Base types in the string category such as citext use the same wire format as text.
+			if typcategory == "S" {
+				return &pgtype.Type{Name: typeName, OID: oid, Codec: pgtype.TextCodec{}}, nil
+			}
+			return nil, errors.New("unsupported base type")
+		}
+
 		dt, ok := c.TypeMap().TypeForOID(elementOID)
 		if !ok {
 			return nil, errors.New("array element OID not registered")
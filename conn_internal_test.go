@@ -53,3 +53,17 @@ func TestStmtCacheSizeLimit(t *testing.T) {
 	assert.Len(t, conn.preparedStatements, cacheLimit+1)
 	assert.Equal(t, cacheLimit, conn.statementCache.Len())
 }
+
+func TestCopyToOptionsWithClause(t *testing.T) {
+	for i, tt := range []struct {
+		opts     CopyToOptions
+		expected string
+	}{
+		{CopyToOptions{}, ""},
+		{CopyToOptions{Format: "csv"}, " with (format 'csv')"},
+		{CopyToOptions{Format: "csv", Header: true, Delimiter: ";", Quote: "'"}, ` with (format 'csv', header true, delimiter ';', quote '''')`},
+		{CopyToOptions{Header: true}, " with (header true)"},
+	} {
+		assert.Equalf(t, tt.expected, tt.opts.withClause(), "%d", i)
+	}
+}
@@ -3,14 +3,21 @@ package pgx_test
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/internal/pgmock"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
 	"github.com/stretchr/testify/assert"
@@ -197,6 +204,60 @@ func TestParseConfigExtractsDefaultQueryExecMode(t *testing.T) {
 	}
 }
 
+func TestParseConfigExtractsDisableBinaryFormat(t *testing.T) {
+	t.Parallel()
+
+	config, err := pgx.ParseConfig("")
+	require.NoError(t, err)
+	require.False(t, config.DisableBinaryFormat)
+
+	config, err = pgx.ParseConfig("disable_binary_format=true")
+	require.NoError(t, err)
+	require.True(t, config.DisableBinaryFormat)
+	require.Empty(t, config.RuntimeParams["disable_binary_format"])
+}
+
+func TestConnectWithDisableBinaryFormat(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	ctr := defaultConnTestRunner
+	ctr.CreateConfig = func(ctx context.Context, t testing.TB) *pgx.ConnConfig {
+		config := defaultConnTestRunner.CreateConfig(ctx, t)
+		config.DisableBinaryFormat = true
+		return config
+	}
+
+	pgxtest.RunWithQueryExecModes(ctx, t, ctr, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var (
+			n   int64
+			num pgtype.Numeric
+			ts  time.Time
+			arr []int32
+			b   []byte
+		)
+		rows, err := conn.Query(ctx, "select 42::int8, 1.5::numeric, '2023-05-17 12:34:56Z'::timestamptz, '{1,2,3}'::int4[], '\x00ff'::bytea")
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+		for _, fd := range rows.FieldDescriptions() {
+			require.EqualValues(t, pgx.TextFormatCode, fd.Format)
+		}
+		require.NoError(t, rows.Scan(&n, &num, &ts, &arr, &b))
+		rows.Close()
+		require.NoError(t, rows.Err())
+
+		require.EqualValues(t, 42, n)
+		f, err := num.Float64Value()
+		require.NoError(t, err)
+		require.Equal(t, 1.5, f.Float64)
+		require.True(t, ts.Equal(time.Date(2023, 5, 17, 12, 34, 56, 0, time.UTC)))
+		require.Equal(t, []int32{1, 2, 3}, arr)
+		require.Equal(t, []byte{0, 255}, b)
+	})
+}
+
 func TestParseConfigErrors(t *testing.T) {
 	t.Parallel()
 
@@ -205,6 +266,7 @@ func TestParseConfigErrors(t *testing.T) {
 		expectedErrSubstring string
 	}{
 		{"default_query_exec_mode=does_not_exist", "does_not_exist"},
+		{"disable_binary_format=maybe", "disable_binary_format"},
 	} {
 		config, err := pgx.ParseConfig(tt.connString)
 		require.Nil(t, config)
@@ -212,6 +274,380 @@ func TestParseConfigErrors(t *testing.T) {
 	}
 }
 
+func TestConnectForcesISODateStyle(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	// Set DateStyle through the startup options so it is the server default rather than an explicit runtime param.
+	config := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	config.RuntimeParams["options"] = "-c DateStyle=German,DMY"
+	require.True(t, config.ForceISODateStyle)
+
+	conn := mustConnect(t, config)
+	defer closeConn(t, conn)
+
+	if conn.PgConn().ParameterStatus("crdb_version") != "" {
+		t.Skip("Server does not support DateStyle German")
+	}
+
+	require.Equal(t, "ISO, DMY", conn.PgConn().ParameterStatus("DateStyle"))
+
+	var ts time.Time
+	err := conn.QueryRow(ctx, "select '2023-11-30 12:34:56'::timestamp", pgx.QueryExecModeSimpleProtocol).Scan(&ts)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 11, 30, 12, 34, 56, 0, time.UTC), ts)
+
+	config.ForceISODateStyle = false
+	conn2 := mustConnect(t, config)
+	defer closeConn(t, conn2)
+
+	require.Equal(t, "German, DMY", conn2.PgConn().ParameterStatus("DateStyle"))
+}
+
+func TestConnectForceISODateStylePreservesExplicitDateStyle(t *testing.T) {
+	t.Parallel()
+
+	config := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	config.RuntimeParams["DateStyle"] = "German, DMY"
+	require.True(t, config.ForceISODateStyle)
+
+	conn := mustConnect(t, config)
+	defer closeConn(t, conn)
+
+	if conn.PgConn().ParameterStatus("crdb_version") != "" {
+		t.Skip("Server does not support DateStyle German")
+	}
+
+	require.Equal(t, "German, DMY", conn.PgConn().ParameterStatus("DateStyle"))
+}
+
+func TestConnectOnParameterStatusNotCalledForSetup(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	script := &pgmock.Script{
+		Steps: []pgmock.Step{
+			pgmock.ExpectAnyMessage(&pgproto3.StartupMessage{ProtocolVersion: pgproto3.ProtocolVersionNumber, Parameters: map[string]string{}}),
+			pgmock.SendMessage(&pgproto3.AuthenticationOk{}),
+			pgmock.SendMessage(&pgproto3.ParameterStatus{Name: "DateStyle", Value: "German, DMY"}),
+			pgmock.SendMessage(&pgproto3.BackendKeyData{ProcessID: 0, SecretKey: 0}),
+			pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'I'}),
+
+			// ForceISODateStyle
+			pgmock.ExpectMessage(&pgproto3.Query{String: "set DateStyle = 'ISO, DMY'"}),
+			pgmock.SendMessage(&pgproto3.ParameterStatus{Name: "DateStyle", Value: "ISO, DMY"}),
+			pgmock.SendMessage(&pgproto3.CommandComplete{CommandTag: []byte("SET")}),
+			pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'I'}),
+
+			pgmock.ExpectMessage(&pgproto3.Query{String: "set time zone 'UTC'"}),
+			pgmock.SendMessage(&pgproto3.ParameterStatus{Name: "TimeZone", Value: "UTC"}),
+			pgmock.SendMessage(&pgproto3.CommandComplete{CommandTag: []byte("SET")}),
+			pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'I'}),
+			pgmock.WaitForClose(),
+		},
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	serverErrChan := make(chan error, 1)
+	go func() {
+		defer close(serverErrChan)
+
+		conn, err := ln.Accept()
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+		defer conn.Close()
+
+		err = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+
+		serverErrChan <- script.Run(pgproto3.NewBackend(conn, conn))
+	}()
+
+	_, port, _ := strings.Cut(ln.Addr().String(), ":")
+	config := mustParseConfig(t, fmt.Sprintf("sslmode=disable host=127.0.0.1 port=%s", port))
+	var changes []string
+	config.OnParameterStatus = func(_ *pgconn.PgConn, name, value string) {
+		changes = append(changes, name+"="+value)
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, config)
+	require.NoError(t, err)
+	require.Equal(t, "ISO, DMY", conn.PgConn().ParameterStatus("DateStyle"))
+	require.Empty(t, changes)
+
+	_, err = conn.PgConn().Exec(ctx, "set time zone 'UTC'").ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"TimeZone=UTC"}, changes)
+
+	require.NoError(t, conn.Close(ctx))
+	require.NoError(t, <-serverErrChan)
+}
+
+func TestConnectDefaultQueryTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	config.DefaultQueryTimeout = 250 * time.Millisecond
+
+	conn := mustConnect(t, config)
+	defer closeConn(t, conn)
+
+	var statementTimeout string
+	err := conn.QueryRow(ctx, "show statement_timeout").Scan(&statementTimeout)
+	require.NoError(t, err)
+	require.Equal(t, "250ms", statementTimeout)
+
+	_, err = conn.Exec(ctx, "select pg_sleep(5)")
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "57014", pgErr.Code)
+	ensureConnValid(t, conn)
+
+	tx, err := conn.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "set local statement_timeout = 0")
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "select pg_sleep(0.5)")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+
+	err = conn.QueryRow(ctx, "show statement_timeout").Scan(&statementTimeout)
+	require.NoError(t, err)
+	require.Equal(t, "250ms", statementTimeout)
+
+	// Sent at startup so it is the session default.
+	_, err = conn.Exec(ctx, "set statement_timeout = 0")
+	require.NoError(t, err)
+	_, err = conn.Exec(ctx, "reset all")
+	require.NoError(t, err)
+	err = conn.QueryRow(ctx, "show statement_timeout").Scan(&statementTimeout)
+	require.NoError(t, err)
+	require.Equal(t, "250ms", statementTimeout)
+}
+
+func TestConnectDefaultQueryTimeoutDoesNotOverrideRuntimeParams(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	config.RuntimeParams["Statement_Timeout"] = "3s"
+	config.DefaultQueryTimeout = 250 * time.Millisecond
+
+	conn := mustConnect(t, config)
+	defer closeConn(t, conn)
+
+	var statementTimeout string
+	err := conn.QueryRow(ctx, "show statement_timeout").Scan(&statementTimeout)
+	require.NoError(t, err)
+	require.Equal(t, "3s", statementTimeout)
+}
+
+// receiveStartupMessageStep receives the next message from the client which must be a StartupMessage and stores it in
+// startupMessage.
+type receiveStartupMessageStep struct {
+	startupMessage *pgproto3.StartupMessage
+}
+
+func (s *receiveStartupMessageStep) Step(backend *pgproto3.Backend) error {
+	msg, err := backend.ReceiveStartupMessage()
+	if err != nil {
+		return err
+	}
+
+	startupMessage, ok := msg.(*pgproto3.StartupMessage)
+	if !ok {
+		return fmt.Errorf("expected StartupMessage, got %#v", msg)
+	}
+	s.startupMessage = &pgproto3.StartupMessage{ProtocolVersion: startupMessage.ProtocolVersion, Parameters: make(map[string]string)}
+	for k, v := range startupMessage.Parameters {
+		s.startupMessage.Parameters[k] = v
+	}
+	return nil
+}
+
+func TestConnectDefaultQueryTimeoutSentInStartupMessage(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	startupStep := &receiveStartupMessageStep{}
+
+	script := &pgmock.Script{
+		Steps: []pgmock.Step{
+			startupStep,
+			pgmock.SendMessage(&pgproto3.AuthenticationOk{}),
+			pgmock.SendMessage(&pgproto3.BackendKeyData{ProcessID: 0, SecretKey: 0}),
+			pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'I'}),
+			pgmock.WaitForClose(),
+		},
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	serverErrChan := make(chan error, 1)
+	go func() {
+		defer close(serverErrChan)
+
+		conn, err := ln.Accept()
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+		defer conn.Close()
+
+		err = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+
+		serverErrChan <- script.Run(pgproto3.NewBackend(conn, conn))
+	}()
+
+	_, port, _ := strings.Cut(ln.Addr().String(), ":")
+	config := mustParseConfig(t, fmt.Sprintf("sslmode=disable host=127.0.0.1 port=%s", port))
+	config.DefaultQueryTimeout = 1500 * time.Microsecond
+
+	conn, err := pgx.ConnectConfig(ctx, config)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close(ctx))
+	require.NoError(t, <-serverErrChan)
+
+	require.Equal(t, "1", startupStep.startupMessage.Parameters["statement_timeout"])
+}
+
+// receiveBindStep receives the next message from the client which must be a Bind and stores it in bind.
+type receiveBindStep struct {
+	bind *pgproto3.Bind
+}
+
+func (s *receiveBindStep) Step(backend *pgproto3.Backend) error {
+	msg, err := backend.Receive()
+	if err != nil {
+		return err
+	}
+
+	bind, ok := msg.(*pgproto3.Bind)
+	if !ok {
+		return fmt.Errorf("expected Bind, got %#v", msg)
+	}
+	s.bind = &pgproto3.Bind{
+		ParameterFormatCodes: append([]int16(nil), bind.ParameterFormatCodes...),
+		ResultFormatCodes:    append([]int16(nil), bind.ResultFormatCodes...),
+	}
+	for _, v := range bind.Parameters {
+		s.bind.Parameters = append(s.bind.Parameters, append([]byte(nil), v...))
+	}
+	return nil
+}
+
+func TestConnectFloatingPointDatetimes(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	ts := time.Date(2023, 11, 30, 12, 34, 56, 500000000, time.UTC)
+	// float8 seconds since 2000-01-01 00:00:00 UTC.
+	floatTimestamp := binary.BigEndian.AppendUint64(nil, math.Float64bits(754662896.5))
+
+	bindStep := &receiveBindStep{}
+
+	script := &pgmock.Script{
+		Steps: []pgmock.Step{
+			pgmock.ExpectAnyMessage(&pgproto3.StartupMessage{ProtocolVersion: pgproto3.ProtocolVersionNumber, Parameters: map[string]string{}}),
+			pgmock.SendMessage(&pgproto3.AuthenticationOk{}),
+			pgmock.SendMessage(&pgproto3.ParameterStatus{Name: "integer_datetimes", Value: "off"}),
+			pgmock.SendMessage(&pgproto3.BackendKeyData{ProcessID: 0, SecretKey: 0}),
+			pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'I'}),
+
+			pgmock.ExpectAnyMessage(&pgproto3.Parse{}),
+			pgmock.ExpectAnyMessage(&pgproto3.Describe{}),
+			pgmock.ExpectAnyMessage(&pgproto3.Sync{}),
+			pgmock.SendMessage(&pgproto3.ParseComplete{}),
+			pgmock.SendMessage(&pgproto3.ParameterDescription{ParameterOIDs: []uint32{pgtype.TimestamptzOID}}),
+			pgmock.SendMessage(&pgproto3.RowDescription{Fields: []pgproto3.FieldDescription{
+				{Name: []byte("ts"), DataTypeOID: pgtype.TimestamptzOID, DataTypeSize: 8, TypeModifier: -1},
+			}}),
+			pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'I'}),
+
+			bindStep,
+			pgmock.ExpectAnyMessage(&pgproto3.Describe{}),
+			pgmock.ExpectAnyMessage(&pgproto3.Execute{}),
+			pgmock.ExpectAnyMessage(&pgproto3.Sync{}),
+			pgmock.SendMessage(&pgproto3.BindComplete{}),
+			pgmock.SendMessage(&pgproto3.RowDescription{Fields: []pgproto3.FieldDescription{
+				{Name: []byte("ts"), DataTypeOID: pgtype.TimestamptzOID, DataTypeSize: 8, TypeModifier: -1, Format: pgx.BinaryFormatCode},
+			}}),
+			pgmock.SendMessage(&pgproto3.DataRow{Values: [][]byte{floatTimestamp}}),
+			pgmock.SendMessage(&pgproto3.CommandComplete{CommandTag: []byte("SELECT 1")}),
+			pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'I'}),
+			pgmock.WaitForClose(),
+		},
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	serverErrChan := make(chan error, 1)
+	go func() {
+		defer close(serverErrChan)
+
+		conn, err := ln.Accept()
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+		defer conn.Close()
+
+		err = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+
+		serverErrChan <- script.Run(pgproto3.NewBackend(conn, conn))
+	}()
+
+	_, port, _ := strings.Cut(ln.Addr().String(), ":")
+	conn, err := pgx.Connect(ctx, fmt.Sprintf("sslmode=disable host=127.0.0.1 port=%s", port))
+	require.NoError(t, err)
+	require.True(t, conn.TypeMap().FloatDatetimes())
+
+	var result time.Time
+	err = conn.QueryRow(ctx, "select $1::timestamptz", pgx.QueryExecModeDescribeExec, ts).Scan(&result)
+	require.NoError(t, err)
+	require.True(t, ts.Equal(result))
+
+	require.Equal(t, []int16{pgx.BinaryFormatCode}, bindStep.bind.ParameterFormatCodes)
+	require.Equal(t, []int16{pgx.BinaryFormatCode}, bindStep.bind.ResultFormatCodes)
+	require.Equal(t, [][]byte{floatTimestamp}, bindStep.bind.Parameters)
+
+	require.NoError(t, conn.Close(ctx))
+	require.NoError(t, <-serverErrChan)
+}
+
 func TestExec(t *testing.T) {
 	t.Parallel()
 
@@ -249,6 +685,68 @@ func TestExec(t *testing.T) {
 	})
 }
 
+func TestExecCommandTag(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		commandTag := mustExec(t, conn, "create temporary table foo(id integer primary key)")
+		require.Equal(t, "CREATE TABLE", commandTag.String())
+		require.False(t, commandTag.Insert() || commandTag.Update() || commandTag.Delete() || commandTag.Select())
+		require.EqualValues(t, 0, commandTag.RowsAffected())
+
+		commandTag = mustExec(t, conn, "insert into foo(id) select n from generate_series(1, $1::int4) n", 3)
+		require.True(t, commandTag.Insert())
+		require.EqualValues(t, 3, commandTag.RowsAffected())
+
+		commandTag = mustExec(t, conn, "update foo set id = id + 10 where id > $1", 1)
+		require.True(t, commandTag.Update())
+		require.EqualValues(t, 2, commandTag.RowsAffected())
+
+		commandTag = mustExec(t, conn, "select * from foo")
+		require.True(t, commandTag.Select())
+		require.EqualValues(t, 3, commandTag.RowsAffected())
+
+		commandTag = mustExec(t, conn, "delete from foo where id = $1", 42)
+		require.True(t, commandTag.Delete())
+		require.EqualValues(t, 0, commandTag.RowsAffected())
+
+		commandTag = mustExec(t, conn, "delete from foo")
+		require.True(t, commandTag.Delete())
+		require.EqualValues(t, 3, commandTag.RowsAffected())
+	})
+}
+
+func TestExecWithArgumentsDiscardsRows(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		mustExec(t, conn, "create temporary table foo(id integer primary key, name text not null)")
+
+		commandTag := mustExec(t, conn, "insert into foo(id, name) values ($1, $2), ($3, $4) returning id", 1, "a", 2, "b")
+		require.True(t, commandTag.Insert())
+		require.EqualValues(t, 2, commandTag.RowsAffected())
+
+		commandTag = mustExec(t, conn, "update foo set name = $1 where id = $2 returning *", "c", 2)
+		require.True(t, commandTag.Update())
+		require.EqualValues(t, 1, commandTag.RowsAffected())
+
+		commandTag = mustExec(t, conn, "delete from foo where name = $1 returning id", "c")
+		require.True(t, commandTag.Delete())
+		require.EqualValues(t, 1, commandTag.RowsAffected())
+
+		var n int64
+		err := conn.QueryRow(ctx, "select count(*) from foo").Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, n)
+	})
+}
+
 type testQueryRewriter struct {
 	sql  string
 	args []any
@@ -271,6 +769,77 @@ func TestExecWithQueryRewriter(t *testing.T) {
 	})
 }
 
+type commentQueryRewriter struct {
+	comment string
+}
+
+func (qr *commentQueryRewriter) RewriteQuery(ctx context.Context, conn *pgx.Conn, sql string, args []any) (newSQL string, newArgs []any, err error) {
+	return "/* " + qr.comment + " */ " + sql, args, nil
+}
+
+func TestConnConfigQueryRewriter(t *testing.T) {
+	t.Parallel()
+
+	ctr := defaultConnTestRunner
+	ctr.CreateConfig = func(ctx context.Context, t testing.TB) *pgx.ConnConfig {
+		config := defaultConnTestRunner.CreateConfig(ctx, t)
+		config.QueryRewriter = &commentQueryRewriter{comment: "trace-id"}
+		return config
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, ctr, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var query string
+		err := conn.QueryRow(ctx, "select current_query()").Scan(&query)
+		require.NoError(t, err)
+		require.Equal(t, "/* trace-id */ select current_query()", query)
+
+		// Applied after a QueryRewriter passed as an argument.
+		err = conn.QueryRow(ctx, "select current_query(), @n::int", pgx.NamedArgs{"n": 1}).Scan(&query, nil)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(query, "/* trace-id */ select current_query(), "), query)
+
+		_, err = conn.Exec(ctx, "select $1::int", 1)
+		require.NoError(t, err)
+
+		batch := &pgx.Batch{}
+		batch.Queue("select current_query()")
+		br := conn.SendBatch(ctx, batch)
+		err = br.QueryRow().Scan(&query)
+		require.NoError(t, err)
+		require.Equal(t, "/* trace-id */ select current_query()", query)
+		err = br.Close()
+		require.NoError(t, err)
+
+		err = conn.ExecuteScript(ctx, "create temporary table query_rewriter_test as select current_query() as q")
+		require.NoError(t, err)
+		err = conn.QueryRow(ctx, "select q from query_rewriter_test").Scan(&query)
+		require.NoError(t, err)
+		require.Equal(t, "/* trace-id */ create temporary table query_rewriter_test as select current_query() as q", query)
+
+		rows, err := conn.SimpleQuery(ctx, "select current_query()")
+		require.NoError(t, err)
+		query, err = pgx.CollectOneRow(rows, pgx.RowTo[string])
+		require.NoError(t, err)
+		require.Equal(t, "/* trace-id */ select current_query()", query)
+
+		rows, err = conn.SimpleQueryArgs(ctx, "select current_query() where $1::int = 1", 1)
+		require.NoError(t, err)
+		query, err = pgx.CollectOneRow(rows, pgx.RowTo[string])
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(query, "/* trace-id */ select current_query() where "), query)
+
+		// Not applied to an explicitly prepared statement.
+		_, err = conn.Prepare(ctx, "ps", "select current_query()")
+		require.NoError(t, err)
+		err = conn.QueryRow(ctx, "ps").Scan(&query)
+		require.NoError(t, err)
+		require.Equal(t, "select current_query()", query)
+	})
+}
+
 func TestExecFailure(t *testing.T) {
 	t.Parallel()
 
@@ -290,6 +859,38 @@ func TestExecFailure(t *testing.T) {
 	})
 }
 
+func TestExecuteScript(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	defaultConnTestRunner.RunTest(ctx, t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		err := conn.ExecuteScript(ctx, `create temporary table script_test(id int primary key);
+insert into script_test values (1), (2);
+select * from script_test;`)
+		require.NoError(t, err)
+
+		err = conn.ExecuteScript(ctx, `insert into script_test values (3);
+select * from script_test;
+insert into script_test values (1);
+insert into script_test values (4);`)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "script statement 3 failed")
+		var pgErr *pgconn.PgError
+		require.ErrorAs(t, err, &pgErr)
+		require.Equal(t, "23505", pgErr.Code)
+
+		// A simple protocol script is run in an implicit transaction so the whole script was rolled back.
+		var n int64
+		err = conn.QueryRow(ctx, "select count(*) from script_test").Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, n)
+
+		ensureConnValid(t, conn)
+	})
+}
+
 func TestExecFailureWithArguments(t *testing.T) {
 	t.Parallel()
 
@@ -420,50 +1021,148 @@ func TestPrepare(t *testing.T) {
 	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
 	defer closeConn(t, conn)
 
-	_, err := conn.Prepare(context.Background(), "test", "select $1::varchar")
-	if err != nil {
-		t.Errorf("Unable to prepare statement: %v", err)
-		return
-	}
-
-	var s string
-	err = conn.QueryRow(context.Background(), "test", "hello").Scan(&s)
-	if err != nil {
-		t.Errorf("Executing prepared statement failed: %v", err)
-	}
-
-	if s != "hello" {
-		t.Errorf("Prepared statement did not return expected value: %v", s)
-	}
+	_, err := conn.Prepare(context.Background(), "test", "select $1::varchar")
+	if err != nil {
+		t.Errorf("Unable to prepare statement: %v", err)
+		return
+	}
+
+	var s string
+	err = conn.QueryRow(context.Background(), "test", "hello").Scan(&s)
+	if err != nil {
+		t.Errorf("Executing prepared statement failed: %v", err)
+	}
+
+	if s != "hello" {
+		t.Errorf("Prepared statement did not return expected value: %v", s)
+	}
+
+	err = conn.Deallocate(context.Background(), "test")
+	if err != nil {
+		t.Errorf("conn.Deallocate failed: %v", err)
+	}
+
+	// Create another prepared statement to ensure Deallocate left the connection
+	// in a working state and that we can reuse the prepared statement name.
+
+	_, err = conn.Prepare(context.Background(), "test", "select $1::integer")
+	if err != nil {
+		t.Errorf("Unable to prepare statement: %v", err)
+		return
+	}
+
+	var n int32
+	err = conn.QueryRow(context.Background(), "test", int32(1)).Scan(&n)
+	if err != nil {
+		t.Errorf("Executing prepared statement failed: %v", err)
+	}
+
+	if n != 1 {
+		t.Errorf("Prepared statement did not return expected value: %v", s)
+	}
+
+	err = conn.DeallocateAll(context.Background())
+	if err != nil {
+		t.Errorf("conn.Deallocate failed: %v", err)
+	}
+}
+
+func TestConnPreparedStatements(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	require.Empty(t, conn.PreparedStatements())
+
+	_, err := conn.Prepare(ctx, "ps_b", "select $1::int4")
+	require.NoError(t, err)
+	_, err = conn.Prepare(ctx, "ps_a", "select $1::text")
+	require.NoError(t, err)
+
+	sds := conn.PreparedStatements()
+	require.Len(t, sds, 2)
+	require.Equal(t, "ps_a", sds[0].Name)
+	require.Equal(t, "select $1::text", sds[0].SQL)
+	require.Equal(t, []uint32{pgtype.TextOID}, sds[0].ParamOIDs)
+	require.Equal(t, "ps_b", sds[1].Name)
+	require.Equal(t, "select $1::int4", sds[1].SQL)
+
+	err = conn.Deallocate(ctx, "ps_b")
+	require.NoError(t, err)
+
+	sds = conn.PreparedStatements()
+	require.Len(t, sds, 1)
+	require.Equal(t, "ps_a", sds[0].Name)
+
+	err = conn.DeallocateAll(ctx)
+	require.NoError(t, err)
+	require.Empty(t, conn.PreparedStatements())
+}
+
+func TestConnPreparedStatementsIncludesStatementCache(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	_, err := conn.Exec(ctx, "select $1::int4", pgx.QueryExecModeCacheStatement, 1)
+	require.NoError(t, err)
+
+	batch := &pgx.Batch{}
+	batch.Queue("select $1::text", "a")
+	err = conn.SendBatch(ctx, batch).Close()
+	require.NoError(t, err)
+
+	sds := conn.PreparedStatements()
+	require.Len(t, sds, 2)
+	sqls := []string{sds[0].SQL, sds[1].SQL}
+	require.ElementsMatch(t, []string{"select $1::int4", "select $1::text"}, sqls)
+}
+
+func TestDeallocateFailureKeepsStatement(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	_, err := conn.Prepare(ctx, "ps", "select $1::int4")
+	require.NoError(t, err)
 
-	err = conn.Deallocate(context.Background(), "test")
-	if err != nil {
-		t.Errorf("conn.Deallocate failed: %v", err)
-	}
+	tx, err := conn.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "select 1/0")
+	require.Error(t, err)
 
-	// Create another prepared statement to ensure Deallocate left the connection
-	// in a working state and that we can reuse the prepared statement name.
+	// The transaction is aborted so the server does not deallocate the statement.
+	err = conn.Deallocate(ctx, "ps")
+	require.Error(t, err)
 
-	_, err = conn.Prepare(context.Background(), "test", "select $1::integer")
-	if err != nil {
-		t.Errorf("Unable to prepare statement: %v", err)
-		return
-	}
+	err = tx.Rollback(ctx)
+	require.NoError(t, err)
 
 	var n int32
-	err = conn.QueryRow(context.Background(), "test", int32(1)).Scan(&n)
-	if err != nil {
-		t.Errorf("Executing prepared statement failed: %v", err)
-	}
+	err = conn.QueryRow(ctx, "ps", 7).Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, n)
 
-	if n != 1 {
-		t.Errorf("Prepared statement did not return expected value: %v", s)
-	}
+	err = conn.Deallocate(ctx, "ps")
+	require.NoError(t, err)
 
-	err = conn.DeallocateAll(context.Background())
-	if err != nil {
-		t.Errorf("conn.Deallocate failed: %v", err)
-	}
+	// The name can be reused after a successful Deallocate.
+	_, err = conn.Prepare(ctx, "ps", "select $1::text")
+	require.NoError(t, err)
+
+	ensureConnValid(t, conn)
 }
 
 func TestPrepareBadSQLFailure(t *testing.T) {
@@ -479,6 +1178,31 @@ func TestPrepareBadSQLFailure(t *testing.T) {
 	ensureConnValid(t, conn)
 }
 
+func TestPreparedStatementWrongNumberOfArguments(t *testing.T) {
+	t.Parallel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	_, err := conn.Prepare(context.Background(), "twoParams", "select $1::int4 + $2::int4")
+	require.NoError(t, err)
+
+	_, err = conn.Exec(context.Background(), "twoParams", 1)
+	require.ErrorIs(t, err, pgx.ErrWrongNumberOfArguments)
+	require.EqualError(t, err, "wrong number of arguments: expected 2, got 1")
+
+	var n int32
+	err = conn.QueryRow(context.Background(), "twoParams", 1, 2, 3).Scan(&n)
+	require.ErrorIs(t, err, pgx.ErrWrongNumberOfArguments)
+	require.EqualError(t, err, "wrong number of arguments: expected 2, got 3")
+
+	err = conn.QueryRow(context.Background(), "twoParams", 1, 2).Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, n)
+
+	ensureConnValid(t, conn)
+}
+
 func TestPrepareIdempotency(t *testing.T) {
 	t.Parallel()
 
@@ -507,6 +1231,9 @@ func TestPrepareIdempotency(t *testing.T) {
 		t.Fatalf("Prepare statement with same name but different SQL should have failed but it didn't")
 		return
 	}
+	require.ErrorContains(t, err, `prepared statement "test" already exists with different SQL`)
+
+	ensureConnValid(t, conn)
 }
 
 func TestPrepareStatementCacheModes(t *testing.T) {
@@ -548,6 +1275,318 @@ func TestPrepareWithDigestedName(t *testing.T) {
 	})
 }
 
+func TestPrepareWithDigestedNameIsParsedOnce(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	tracer := &testTracer{}
+
+	config := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	config.Tracer = tracer
+
+	conn := mustConnect(t, config)
+	defer closeConn(t, conn)
+
+	var alreadyPrepared []bool
+	tracer.tracePrepareEnd = func(ctx context.Context, conn *pgx.Conn, data pgx.TracePrepareEndData) {
+		alreadyPrepared = append(alreadyPrepared, data.AlreadyPrepared)
+	}
+
+	sql := "select $1::int4 + 1"
+	sd1, err := conn.Prepare(ctx, sql, sql)
+	require.NoError(t, err)
+	sd2, err := conn.Prepare(ctx, sql, sql)
+	require.NoError(t, err)
+	require.Same(t, sd1, sd2)
+	require.Equal(t, []bool{false, true}, alreadyPrepared)
+
+	var n int64
+	err = conn.QueryRow(ctx, "select count(*) from pg_prepared_statements where name = $1", sd1.Name).Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+
+	ensureConnValid(t, conn)
+}
+
+func TestPrepareWithParamOIDs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		_, err := conn.Prepare(ctx, "untyped", "select $1")
+		require.Error(t, err)
+
+		sd, err := conn.PrepareWithParamOIDs(ctx, "typed", "select $1, $2::text", []uint32{pgtype.Int8OID, 0})
+		require.NoError(t, err)
+		require.Equal(t, []uint32{pgtype.Int8OID, pgtype.TextOID}, sd.ParamOIDs)
+
+		var n int64
+		var s string
+		err = conn.QueryRow(ctx, "typed", 42, "hello").Scan(&n, &s)
+		require.NoError(t, err)
+		require.EqualValues(t, 42, n)
+		require.Equal(t, "hello", s)
+
+		// Preparing again with the same name, SQL, and parameter types is idempotent.
+		_, err = conn.PrepareWithParamOIDs(ctx, "typed", "select $1, $2::text", []uint32{pgtype.Int8OID})
+		require.NoError(t, err)
+
+		err = conn.Deallocate(ctx, "typed")
+		require.NoError(t, err)
+	})
+}
+
+func TestConnPrepareBatch(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	stmts := map[string]string{
+		"add":                 "select $1::int4 + $2::int4",
+		"upper":               "select upper($1::text)",
+		"select 42::int8":     "select 42::int8",
+		"generate_series_sum": "select sum(n) from generate_series(1, $1::int4) n",
+	}
+	err := conn.PrepareBatch(ctx, stmts)
+	require.NoError(t, err)
+	require.Len(t, conn.PreparedStatements(), 4)
+
+	var n int64
+	err = conn.QueryRow(ctx, "add", 1, 2).Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, n)
+
+	err = conn.QueryRow(ctx, "select 42::int8").Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 42, n)
+
+	var s string
+	err = conn.QueryRow(ctx, "upper", "foo").Scan(&s)
+	require.NoError(t, err)
+	require.Equal(t, "FOO", s)
+
+	// Preparing the same statements again is idempotent.
+	err = conn.PrepareBatch(ctx, stmts)
+	require.NoError(t, err)
+
+	err = conn.PrepareBatch(ctx, map[string]string{"add": "select $1::int8 + $2::int8"})
+	require.ErrorContains(t, err, `"add"`)
+
+	ensureConnValid(t, conn)
+}
+
+func TestConnPrepareBatchFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	stmts := map[string]string{
+		"ps_a": "select $1::int4",
+		"ps_b": "selct $1::int4",
+		"ps_c": "select $1::text",
+	}
+	err := conn.PrepareBatch(ctx, stmts)
+	require.ErrorContains(t, err, `"ps_b"`)
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "42601", pgErr.Code)
+	require.Empty(t, conn.PreparedStatements())
+
+	ensureConnValid(t, conn)
+
+	// ps_a was deallocated on the server so it can be prepared again.
+	delete(stmts, "ps_b")
+	err = conn.PrepareBatch(ctx, stmts)
+	require.NoError(t, err)
+	require.Len(t, conn.PreparedStatements(), 2)
+}
+
+// writeCountingConn counts the number of writes to the underlying net.Conn.
+type writeCountingConn struct {
+	net.Conn
+	writes int32
+}
+
+func (c *writeCountingConn) Write(b []byte) (int, error) {
+	atomic.AddInt32(&c.writes, 1)
+	return c.Conn.Write(b)
+}
+
+func TestConnPrepareBatchSingleRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	stmts := make(map[string]string)
+	var steps []pgmock.Step
+	steps = append(steps, pgmock.AcceptUnauthenticatedConnRequestSteps()...)
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("ps_%d", i)
+		sql := fmt.Sprintf("select %d", i)
+		stmts[name] = sql
+		steps = append(steps,
+			pgmock.ExpectMessage(&pgproto3.Parse{Name: name, Query: sql}),
+			pgmock.ExpectMessage(&pgproto3.Describe{ObjectType: 'S', Name: name}),
+		)
+	}
+	// The server does not respond until every statement and the Sync are received.
+	steps = append(steps, pgmock.ExpectMessage(&pgproto3.Sync{}))
+	for i := 0; i < 10; i++ {
+		steps = append(steps,
+			pgmock.SendMessage(&pgproto3.ParseComplete{}),
+			pgmock.SendMessage(&pgproto3.ParameterDescription{}),
+			pgmock.SendMessage(&pgproto3.RowDescription{Fields: []pgproto3.FieldDescription{
+				{Name: []byte("?column?"), DataTypeOID: pgtype.Int4OID, DataTypeSize: 4, TypeModifier: -1},
+			}}),
+		)
+	}
+	steps = append(steps,
+		pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'I'}),
+		pgmock.ExpectMessage(&pgproto3.Terminate{}),
+	)
+	script := &pgmock.Script{Steps: steps}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	serverErrChan := make(chan error, 1)
+	go func() {
+		defer close(serverErrChan)
+
+		conn, err := ln.Accept()
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+		defer conn.Close()
+
+		err = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+
+		serverErrChan <- script.Run(pgproto3.NewBackend(conn, conn))
+	}()
+
+	_, port, _ := strings.Cut(ln.Addr().String(), ":")
+	config, err := pgx.ParseConfig(fmt.Sprintf("sslmode=disable host=127.0.0.1 port=%s", port))
+	require.NoError(t, err)
+
+	var countingConn *writeCountingConn
+	config.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		countingConn = &writeCountingConn{Conn: conn}
+		return countingConn, nil
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, config)
+	require.NoError(t, err)
+
+	writesBefore := atomic.LoadInt32(&countingConn.writes)
+	err = conn.PrepareBatch(ctx, stmts)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&countingConn.writes)-writesBefore)
+	require.Len(t, conn.PreparedStatements(), 10)
+
+	err = conn.Close(ctx)
+	require.NoError(t, err)
+	require.NoError(t, <-serverErrChan)
+}
+
+func TestConnCreatedAtAndLastUsedAt(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	before := time.Now()
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	createdAt := conn.CreatedAt()
+	require.False(t, createdAt.Before(before))
+	require.False(t, createdAt.After(time.Now()))
+	require.Equal(t, createdAt, conn.LastUsedAt())
+
+	time.Sleep(10 * time.Millisecond)
+	_, err := conn.Exec(ctx, "select 1")
+	require.NoError(t, err)
+	require.True(t, conn.LastUsedAt().After(createdAt))
+	require.Equal(t, createdAt, conn.CreatedAt())
+
+	lastUsedAt := conn.LastUsedAt()
+	time.Sleep(10 * time.Millisecond)
+	rows, _ := conn.Query(ctx, "select 1")
+	rows.Close()
+	require.NoError(t, rows.Err())
+	require.True(t, conn.LastUsedAt().After(lastUsedAt))
+}
+
+func TestConnSetResultFormatForOID(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	modes := []pgx.QueryExecMode{
+		pgx.QueryExecModeCacheStatement,
+		pgx.QueryExecModeCacheDescribe,
+		pgx.QueryExecModeDescribeExec,
+	}
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, modes, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		resultFormats := func() []int16 {
+			rows, err := conn.Query(ctx, "select 1.5::numeric, 2::int4")
+			require.NoError(t, err)
+			defer rows.Close()
+
+			var formats []int16
+			for _, fd := range rows.FieldDescriptions() {
+				formats = append(formats, fd.Format)
+			}
+
+			for rows.Next() {
+				var n pgtype.Numeric
+				var i int32
+				require.NoError(t, rows.Scan(&n, &i))
+				f, err := n.Float64Value()
+				require.NoError(t, err)
+				require.Equal(t, 1.5, f.Float64)
+				require.EqualValues(t, 2, i)
+			}
+			require.NoError(t, rows.Err())
+
+			return formats
+		}
+
+		require.Equal(t, []int16{pgtype.BinaryFormatCode, pgtype.BinaryFormatCode}, resultFormats())
+
+		conn.SetResultFormatForOID(pgtype.NumericOID, pgtype.TextFormatCode)
+		require.Equal(t, []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode}, resultFormats())
+
+		conn.ResetResultFormatForOID(pgtype.NumericOID)
+		require.Equal(t, []int16{pgtype.BinaryFormatCode, pgtype.BinaryFormatCode}, resultFormats())
+	})
+}
+
 func TestListenNotify(t *testing.T) {
 	t.Parallel()
 
@@ -1003,6 +2042,31 @@ func TestLoadCompositeType(t *testing.T) {
 	})
 }
 
+func TestLoadCitextType(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var citextOID uint32
+		err := conn.QueryRow(ctx, `select oid from pg_type where typname = 'citext'`).Scan(&citextOID)
+		if err != nil {
+			t.Skipf("Skipping: cannot find citext OID")
+		}
+
+		dt, err := conn.LoadType(ctx, "citext")
+		require.NoError(t, err)
+		require.Equal(t, citextOID, dt.OID)
+		conn.TypeMap().RegisterType(dt)
+
+		var s string
+		var eq bool
+		err = conn.QueryRow(ctx, "select $1::citext, $1::citext = 'HELLO'::citext", "Hello").Scan(&s, &eq)
+		require.NoError(t, err)
+		require.Equal(t, "Hello", s)
+		require.True(t, eq)
+	})
+}
+
 func TestLoadRangeType(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
 	defer cancel()
@@ -1086,6 +2150,47 @@ func TestLoadMultiRangeType(t *testing.T) {
 	})
 }
 
+func TestConnOnStatementCacheEvent(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	var events []pgx.CacheEvent
+
+	config := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	config.StatementCacheCapacity = 2
+	config.OnStatementCacheEvent = func(event pgx.CacheEvent) {
+		events = append(events, event)
+	}
+
+	conn := mustConnect(t, config)
+	defer closeConn(t, conn)
+
+	for _, sql := range []string{"select 1::int4 + $1", "select 2::int4 + $1", "select 1::int4 + $1", "select 3::int4 + $1"} {
+		var n int32
+		err := conn.QueryRow(ctx, sql, pgx.QueryExecModeCacheStatement, 0).Scan(&n)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, []pgx.CacheEvent{
+		{Kind: pgx.CacheEventMiss, SQL: "select 1::int4 + $1", Mode: pgx.QueryExecModeCacheStatement},
+		{Kind: pgx.CacheEventMiss, SQL: "select 2::int4 + $1", Mode: pgx.QueryExecModeCacheStatement},
+		{Kind: pgx.CacheEventHit, SQL: "select 1::int4 + $1", Mode: pgx.QueryExecModeCacheStatement},
+		{Kind: pgx.CacheEventMiss, SQL: "select 3::int4 + $1", Mode: pgx.QueryExecModeCacheStatement},
+		{Kind: pgx.CacheEventEvict, SQL: "select 2::int4 + $1", Mode: pgx.QueryExecModeCacheStatement},
+	}, events)
+
+	events = nil
+	_, err := conn.Exec(ctx, "select 1::int4 + $1", pgx.QueryExecModeCacheDescribe, 0)
+	require.NoError(t, err)
+	require.Equal(t, []pgx.CacheEvent{
+		{Kind: pgx.CacheEventMiss, SQL: "select 1::int4 + $1", Mode: pgx.QueryExecModeCacheDescribe},
+	}, events)
+
+	ensureConnValid(t, conn)
+}
+
 func TestStmtCacheInvalidationConn(t *testing.T) {
 	ctx := context.Background()
 
@@ -5,9 +5,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
+	"github.com/jackc/pgx/v5/internal/anynil"
 	"github.com/jackc/pgx/v5/internal/pgio"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // CopyFromRows returns a CopyFromSource interface over the provided rows slice
@@ -124,14 +127,7 @@ func (ct *copyFrom) run(ctx context.Context) (int64, error) {
 	}
 
 	quotedTableName := ct.tableName.Sanitize()
-	cbuf := &bytes.Buffer{}
-	for i, cn := range ct.columnNames {
-		if i != 0 {
-			cbuf.WriteString(", ")
-		}
-		cbuf.WriteString(quoteIdentifier(cn))
-	}
-	quotedColumnNames := cbuf.String()
+	quotedColumnNames := quoteColumnNames(ct.columnNames)
 
 	var sd *pgconn.StatementDescription
 	switch ct.mode {
@@ -214,6 +210,18 @@ func (ct *copyFrom) run(ctx context.Context) (int64, error) {
 	return commandTag.RowsAffected(), err
 }
 
+// quoteColumnNames returns columnNames quoted and joined for use in a column list.
+func quoteColumnNames(columnNames []string) string {
+	cbuf := &bytes.Buffer{}
+	for i, cn := range columnNames {
+		if i != 0 {
+			cbuf.WriteString(", ")
+		}
+		cbuf.WriteString(quoteIdentifier(cn))
+	}
+	return cbuf.String()
+}
+
 func (ct *copyFrom) buildCopyBuf(buf []byte, sd *pgconn.StatementDescription) (bool, []byte, error) {
 	const sendBufSize = 65536 - 5 // The packet has a 5-byte header
 	lastBufLen := 0
@@ -255,14 +263,21 @@ func (ct *copyFrom) buildCopyBuf(buf []byte, sd *pgconn.StatementDescription) (b
 }
 
 // CopyFrom uses the PostgreSQL copy protocol to perform bulk data insertion. It returns the number of rows copied and
-// an error.
+// an error. The number of rows copied is the count reported by the server in the COPY command tag, not the number of
+// rows read from rowSrc. These can differ when a trigger skips rows.
 //
 // CopyFrom requires all values use the binary format. A pgtype.Type that supports the binary format must be registered
 // for the type of each column. Almost all types implemented by pgx support the binary format.
 //
+// The type of each column is determined by describing a select of columnNames from tableName. Each value is encoded
+// for its column's type rather than for its Go type. For example, a Go int can be copied into an int2, int4, or int8
+// column, and an error is returned if the value is out of range for the column.
+//
 // Even though enum types appear to be strings they still must be registered to use with CopyFrom. This can be done with
 // Conn.LoadType and pgtype.Map.RegisterType.
 func (c *Conn) CopyFrom(ctx context.Context, tableName Identifier, columnNames []string, rowSrc CopyFromSource) (int64, error) {
+	c.lastUsedAt = time.Now()
+
 	ct := &copyFrom{
 		conn:          c,
 		tableName:     tableName,
@@ -274,3 +289,89 @@ func (c *Conn) CopyFrom(ctx context.Context, tableName Identifier, columnNames [
 
 	return ct.run(ctx)
 }
+
+// CopyFromCSV uses the PostgreSQL copy protocol to insert the CSV data read from r into tableName. It returns the number
+// of rows copied and an error. If columnNames is empty the data must contain every column of the table in order.
+//
+// The data is sent to the server unchanged. It must be in the format expected by COPY ... FROM STDIN WITH (FORMAT csv)
+// without a header row. The server parses the data so quoting and escaping follow its rules. If the server rejects a
+// row the returned error is a *pgconn.PgError that identifies the line, and no rows are inserted.
+func (c *Conn) CopyFromCSV(ctx context.Context, tableName Identifier, columnNames []string, r io.Reader) (int64, error) {
+	c.lastUsedAt = time.Now()
+
+	if c.copyFromTracer != nil {
+		ctx = c.copyFromTracer.TraceCopyFromStart(ctx, c, TraceCopyFromStartData{
+			TableName:   tableName,
+			ColumnNames: columnNames,
+		})
+	}
+
+	sql := "copy " + tableName.Sanitize()
+	if len(columnNames) > 0 {
+		sql += " ( " + quoteColumnNames(columnNames) + " )"
+	}
+	sql += " from stdin with (format csv, header false);"
+	commandTag, err := c.pgConn.CopyFrom(ctx, r, sql)
+
+	if c.copyFromTracer != nil {
+		c.copyFromTracer.TraceCopyFromEnd(ctx, c, TraceCopyFromEndData{
+			CommandTag: commandTag,
+			Err:        err,
+		})
+	}
+
+	return commandTag.RowsAffected(), err
+}
+
+// EncodeCopyTextRow encodes values as one line of the PostgreSQL COPY text format using the default type mappings.
+// Values are separated by tabs and the line ends with a newline. A nil value is written as \N. Backslashes, tabs,
+// newlines, and carriage returns in values are escaped. The lines can be sent with *pgconn.PgConn.CopyFrom and a
+// COPY ... FROM STDIN statement that uses the default text format options.
+//
+// EncodeCopyTextRow creates a new pgtype.Map for each call. Use AppendCopyTextRow with Conn.TypeMap when encoding many
+// rows or values of registered custom types.
+func EncodeCopyTextRow(values []any) ([]byte, error) {
+	return AppendCopyTextRow(pgtype.NewMap(), nil, values)
+}
+
+// AppendCopyTextRow is like EncodeCopyTextRow but encodes values with m and appends the line to buf.
+func AppendCopyTextRow(m *pgtype.Map, buf []byte, values []any) ([]byte, error) {
+	valueBuf := make([]byte, 0, 64)
+	for i, value := range values {
+		if i > 0 {
+			buf = append(buf, '\t')
+		}
+
+		if anynil.Is(value) {
+			buf = append(buf, `\N`...)
+			continue
+		}
+
+		encoded, err := m.Encode(0, TextFormatCode, value, valueBuf[:0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode values[%d]: %w", i, err)
+		}
+		if encoded == nil {
+			buf = append(buf, `\N`...)
+			continue
+		}
+		valueBuf = encoded
+
+		for _, b := range encoded {
+			switch b {
+			case '\\':
+				buf = append(buf, `\\`...)
+			case '\t':
+				buf = append(buf, `\t`...)
+			case '\n':
+				buf = append(buf, `\n`...)
+			case '\r':
+				buf = append(buf, `\r`...)
+			default:
+				buf = append(buf, b)
+			}
+		}
+	}
+
+	return append(buf, '\n'), nil
+}
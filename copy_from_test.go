@@ -1,15 +1,18 @@
 package pgx_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
 	"github.com/stretchr/testify/require"
 )
@@ -803,6 +806,91 @@ func TestConnCopyFromAutomaticStringConversion(t *testing.T) {
 	ensureConnValid(t, conn)
 }
 
+func TestConnCopyFromEncodesForColumnType(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		mustExec(t, conn, `create temporary table foo(
+			a int2,
+			b int4,
+			c int8,
+			d text,
+			e bytea
+		)`)
+
+		inputRows := [][]any{
+			{int(1), int(2), int(3), "foo", []byte{0, 1, 2}},
+			{int(-32768), int(2147483647), int(-2147483648), "", []byte{}},
+		}
+
+		copyCount, err := conn.CopyFrom(ctx, pgx.Identifier{"foo"}, []string{"a", "b", "c", "d", "e"}, pgx.CopyFromRows(inputRows))
+		require.NoError(t, err)
+		require.EqualValues(t, len(inputRows), copyCount)
+
+		type row struct {
+			A int16
+			B int32
+			C int64
+			D string
+			E []byte
+		}
+		rows, _ := conn.Query(ctx, "select * from foo order by a desc")
+		results, err := pgx.CollectRows(rows, pgx.RowToStructByPos[row])
+		require.NoError(t, err)
+		require.Equal(t, []row{
+			{A: 1, B: 2, C: 3, D: "foo", E: []byte{0, 1, 2}},
+			{A: -32768, B: 2147483647, C: -2147483648, D: "", E: []byte{}},
+		}, results)
+
+		_, err = conn.CopyFrom(ctx, pgx.Identifier{"foo"}, []string{"a"}, pgx.CopyFromRows([][]any{{int(32768)}}))
+		require.Error(t, err)
+
+		ensureConnValid(t, conn)
+	})
+}
+
+func TestConnCopyFromReturnsServerRowCount(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	pgxtest.SkipCockroachDB(t, conn, "Server does not support triggers")
+
+	mustExec(t, conn, `create temporary table foo(a int4)`)
+	mustExec(t, conn, `create function pg_temp.skip_odd() returns trigger language plpgsql as $$
+begin
+	if new.a % 2 = 1 then
+		return null;
+	end if;
+	return new;
+end
+$$`)
+	mustExec(t, conn, `create trigger skip_odd before insert on foo for each row execute function pg_temp.skip_odd()`)
+
+	inputRows := make([][]any, 10)
+	for i := range inputRows {
+		inputRows[i] = []any{int32(i)}
+	}
+
+	copyCount, err := conn.CopyFrom(ctx, pgx.Identifier{"foo"}, []string{"a"}, pgx.CopyFromRows(inputRows))
+	require.NoError(t, err)
+	require.EqualValues(t, 5, copyCount)
+
+	var n int64
+	err = conn.QueryRow(ctx, "select count(*) from foo").Scan(&n)
+	require.NoError(t, err)
+	require.Equal(t, n, copyCount)
+
+	ensureConnValid(t, conn)
+}
+
 func TestCopyFromFunc(t *testing.T) {
 	t.Parallel()
 
@@ -858,3 +946,127 @@ func TestCopyFromFunc(t *testing.T) {
 
 	ensureConnValid(t, conn)
 }
+
+func TestConnCopyFromCSV(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	mustExec(t, conn, `create temporary table foo(
+		a int4,
+		b text
+	)`)
+
+	csv := "1,abc\n2,\"with, comma and \"\"quotes\"\"\"\n3,\n"
+	copyCount, err := conn.CopyFromCSV(ctx, pgx.Identifier{"foo"}, []string{"a", "b"}, strings.NewReader(csv))
+	require.NoError(t, err)
+	require.EqualValues(t, 3, copyCount)
+
+	rows, _ := conn.Query(ctx, "select a, b from foo order by a")
+	type row struct {
+		A int32
+		B *string
+	}
+	actual, err := pgx.CollectRows(rows, pgx.RowToStructByPos[row])
+	require.NoError(t, err)
+
+	abc := "abc"
+	quoted := `with, comma and "quotes"`
+	require.Equal(t, []row{{1, &abc}, {2, &quoted}, {3, nil}}, actual)
+
+	copyCount, err = conn.CopyFromCSV(ctx, pgx.Identifier{"foo"}, []string{"a", "b"}, strings.NewReader("4,ok\nnot a number,bad\n"))
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "22P02", pgErr.Code)
+	require.Contains(t, pgErr.Where, "line 2")
+	require.EqualValues(t, 0, copyCount)
+
+	var count int64
+	err = conn.QueryRow(ctx, "select count(*) from foo").Scan(&count)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, count)
+
+	// All columns are copied when no column names are given.
+	for _, columnNames := range [][]string{nil, {}} {
+		copyCount, err = conn.CopyFromCSV(ctx, pgx.Identifier{"foo"}, columnNames, strings.NewReader("5,all\n"))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, copyCount)
+	}
+
+	err = conn.QueryRow(ctx, "select count(*) from foo where a = 5 and b = 'all'").Scan(&count)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+
+	ensureConnValid(t, conn)
+}
+
+func TestEncodeCopyTextRow(t *testing.T) {
+	t.Parallel()
+
+	for i, tt := range []struct {
+		values   []any
+		expected string
+	}{
+		{[]any{int32(1), "abc"}, "1\tabc\n"},
+		{[]any{"tab\there", "new\nline", "carriage\rreturn", `back\slash`}, "tab\\there\tnew\\nline\tcarriage\\rreturn\tback\\\\slash\n"},
+		{[]any{nil, (*string)(nil), pgtype.Text{}, ""}, "\\N\t\\N\t\\N\t\n"},
+		{[]any{`\N`}, "\\\\N\n"},
+		{[]any{[]byte{0xde, 0xad}, true, 1.5}, "\\\\xdead\tt\t1.5\n"},
+		{[]any{}, "\n"},
+	} {
+		buf, err := pgx.EncodeCopyTextRow(tt.values)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, tt.expected, string(buf), "%d", i)
+	}
+
+	_, err := pgx.EncodeCopyTextRow([]any{1, struct{}{}})
+	require.ErrorContains(t, err, "values[1]")
+}
+
+func TestAppendCopyTextRowCopyFrom(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	mustExec(t, conn, `create temporary table foo(
+		a int4,
+		b text,
+		c bytea
+	)`)
+
+	inputRows := [][]any{
+		{int32(1), "tab\there", []byte{0, '\t', '\\'}},
+		{int32(2), "new\nline\r\n", []byte{}},
+		{int32(3), `back\slash \N`, nil},
+		{int32(4), "", []byte("x")},
+		{nil, nil, nil},
+	}
+
+	var buf []byte
+	for _, row := range inputRows {
+		var err error
+		buf, err = pgx.AppendCopyTextRow(conn.TypeMap(), buf, row)
+		require.NoError(t, err)
+	}
+
+	ct, err := conn.PgConn().CopyFrom(ctx, bytes.NewReader(buf), "copy foo (a, b, c) from stdin")
+	require.NoError(t, err)
+	require.EqualValues(t, len(inputRows), ct.RowsAffected())
+
+	rows, _ := conn.Query(ctx, "select a, b, c from foo order by a nulls last")
+	actual, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) ([]any, error) {
+		return row.Values()
+	})
+	require.NoError(t, err)
+	require.Equal(t, inputRows, actual)
+
+	ensureConnValid(t, conn)
+}
@@ -0,0 +1,70 @@
+package pgx
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/internal/sanitize"
+)
+
+// CopyToOptions controls the format of the data written by *Conn.CopyTo. The zero value writes the PostgreSQL text
+// format with the server's default options.
+type CopyToOptions struct {
+	// Format is the COPY format: "text", "csv", or "binary". If empty, the server default of text is used.
+	Format string
+
+	// Header causes a header row with the column names to be written first. It is supported by the csv format and, as of
+	// PostgreSQL 15, the text format.
+	Header bool
+
+	// Delimiter is the single character that separates columns. If empty, the server default is used. This is a tab
+	// for the text format and a comma for the csv format.
+	Delimiter string
+
+	// Quote is the single character used to quote values in the csv format. If empty, the server default of a double
+	// quote is used.
+	Quote string
+}
+
+// withClause returns the options formatted as a COPY WITH clause or "" if no options are set.
+func (opts CopyToOptions) withClause() string {
+	var options []string
+	if opts.Format != "" {
+		options = append(options, "format "+sanitize.QuoteString(opts.Format))
+	}
+	if opts.Header {
+		options = append(options, "header true")
+	}
+	if opts.Delimiter != "" {
+		options = append(options, "delimiter "+sanitize.QuoteString(opts.Delimiter))
+	}
+	if opts.Quote != "" {
+		options = append(options, "quote "+sanitize.QuoteString(opts.Quote))
+	}
+
+	if len(options) == 0 {
+		return ""
+	}
+	return " with (" + strings.Join(options, ", ") + ")"
+}
+
+// CopyTo uses the PostgreSQL copy protocol to write the contents of tableName to w. It returns the number of rows
+// copied and an error. If columnNames is empty all columns are copied. opts controls the format, header row, delimiter,
+// and quote character. The server validates the options. For example, Quote is rejected unless Format is "csv".
+//
+// The data is written to w as it is received from the server. If an error occurs part of the data may already have
+// been written.
+func (c *Conn) CopyTo(ctx context.Context, w io.Writer, tableName Identifier, columnNames []string, opts CopyToOptions) (int64, error) {
+	c.lastUsedAt = time.Now()
+
+	sql := "copy " + tableName.Sanitize()
+	if len(columnNames) > 0 {
+		sql += " ( " + quoteColumnNames(columnNames) + " )"
+	}
+	sql += " to stdout" + opts.withClause()
+
+	commandTag, err := c.pgConn.CopyTo(ctx, w, sql)
+	return commandTag.RowsAffected(), err
+}
@@ -0,0 +1,74 @@
+package pgx_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnCopyTo(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	mustExec(t, conn, `create temporary table foo(
+		a int4,
+		b text
+	)`)
+	mustExec(t, conn, `insert into foo(a, b) values (1, 'abc'), (2, 'with, comma'), (3, null)`)
+
+	for i, tt := range []struct {
+		columnNames []string
+		opts        pgx.CopyToOptions
+		expected    string
+	}{
+		{
+			columnNames: nil,
+			opts:        pgx.CopyToOptions{},
+			expected:    "1\tabc\n2\twith, comma\n3\t\\N\n",
+		},
+		{
+			columnNames: []string{"b", "a"},
+			opts:        pgx.CopyToOptions{Format: "csv", Header: true},
+			expected:    "b,a\nabc,1\n\"with, comma\",2\n,3\n",
+		},
+		{
+			columnNames: []string{"a", "b"},
+			opts:        pgx.CopyToOptions{Format: "csv", Delimiter: ";", Quote: "'"},
+			expected:    "1;abc\n2;with, comma\n3;\n",
+		},
+		{
+			columnNames: []string{"a", "b"},
+			opts:        pgx.CopyToOptions{Format: "csv", Delimiter: ",", Quote: "'"},
+			expected:    "1,abc\n2,'with, comma'\n3,\n",
+		},
+		{
+			columnNames: []string{"a", "b"},
+			opts:        pgx.CopyToOptions{Delimiter: "|"},
+			expected:    "1|abc\n2|with, comma\n3|\\N\n",
+		},
+	} {
+		var buf bytes.Buffer
+		copyCount, err := conn.CopyTo(ctx, &buf, pgx.Identifier{"foo"}, tt.columnNames, tt.opts)
+		require.NoErrorf(t, err, "%d", i)
+		require.EqualValuesf(t, 3, copyCount, "%d", i)
+		require.Equalf(t, tt.expected, buf.String(), "%d", i)
+	}
+
+	var buf bytes.Buffer
+	_, err := conn.CopyTo(ctx, &buf, pgx.Identifier{"foo"}, nil, pgx.CopyToOptions{Quote: "'"})
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+
+	ensureConnValid(t, conn)
+}
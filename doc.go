@@ -73,6 +73,10 @@ pgx uses the pgtype package to converting Go values to and from PostgreSQL value
 directly and is customizable and extendable. User defined data types such as enums, domains,  and composite types may
 require type registration. See that package's documentation for details.
 
+Servers built with floating point datetimes (integer_datetimes is off, only possible before PostgreSQL 10) use float8
+seconds instead of int64 microseconds in the binary format of time, timestamp, and interval types. pgx reads
+integer_datetimes when connecting and configures the connection's type map accordingly. See pgtype.Map.SetFloatDatetimes.
+
 Transactions
 
 Transactions are started by calling Begin.
@@ -119,6 +123,10 @@ includes an automatic statement cache by default. Queries run through the normal
 automatically prepared on first execution and the prepared statement is reused on subsequent executions. See ParseConfig
 for information on how to customize or disable the statement cache.
 
+The server only describes the type OID of each parameter, not its type modifier. For example, a varchar(10) parameter is
+described as varchar. pgx therefore cannot check lengths or precisions before sending a query. A value that does not fit
+is rejected by the server with a *pgconn.PgError such as code 22001 (string_data_right_truncation).
+
 Copy Protocol
 
 Use CopyFrom to efficiently insert multiple rows at a time using the PostgreSQL copy protocol. CopyFrom accepts a
@@ -185,6 +193,11 @@ Lower Level PostgreSQL Functionality
 github.com/jackc/pgx/v5/pgconn contains a lower level PostgreSQL driver roughly at the level of libpq. pgx.Conn in
 implemented on top of pgconn. The Conn.PgConn() method can be used to access this lower layer.
 
+For latency bound workloads with many independent queries, pgconn.PgConn.StartPipeline can send queries without waiting
+for the results of previous queries. ExtendedQueryBuilder can encode the arguments for each query and
+RowsFromResultReader can read the results as Rows. See pgconn.Pipeline for how errors affect the remaining queries.
+Prefer SendBatch unless results need to be read while more queries are still being sent.
+
 PgBouncer
 
 By default pgx automatically uses prepared statements. Prepared statements are incompaptible with PgBouncer. This can be
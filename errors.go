@@ -0,0 +1,40 @@
+package pgx
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATE codes for integrity constraint violations. See https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	notNullViolationCode    = "23502"
+	foreignKeyViolationCode = "23503"
+	uniqueViolationCode     = "23505"
+	checkViolationCode      = "23514"
+)
+
+// IsUniqueViolation reports whether err is or wraps a *pgconn.PgError for a unique constraint violation.
+func IsUniqueViolation(err error) bool {
+	return hasPgErrorCode(err, uniqueViolationCode)
+}
+
+// IsForeignKeyViolation reports whether err is or wraps a *pgconn.PgError for a foreign key constraint violation.
+func IsForeignKeyViolation(err error) bool {
+	return hasPgErrorCode(err, foreignKeyViolationCode)
+}
+
+// IsCheckViolation reports whether err is or wraps a *pgconn.PgError for a check constraint violation.
+func IsCheckViolation(err error) bool {
+	return hasPgErrorCode(err, checkViolationCode)
+}
+
+// IsNotNullViolation reports whether err is or wraps a *pgconn.PgError for a not null constraint violation.
+func IsNotNullViolation(err error) bool {
+	return hasPgErrorCode(err, notNullViolationCode)
+}
+
+func hasPgErrorCode(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
@@ -0,0 +1,60 @@
+package pgx_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraintViolationPredicates(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		mustExec(t, conn, `create temporary table violation_parent(id int primary key)`)
+		mustExec(t, conn, `create temporary table violation_child(
+	id int primary key,
+	parent_id int references violation_parent,
+	name text not null,
+	n int check (n > 0)
+)`)
+		mustExec(t, conn, `insert into violation_parent(id) values (1)`)
+		mustExec(t, conn, `insert into violation_child(id, parent_id, name, n) values (1, 1, 'a', 1)`)
+
+		predicates := map[string]func(error) bool{
+			"unique":      pgx.IsUniqueViolation,
+			"foreign key": pgx.IsForeignKeyViolation,
+			"check":       pgx.IsCheckViolation,
+			"not null":    pgx.IsNotNullViolation,
+		}
+
+		for _, tt := range []struct {
+			violation string
+			sql       string
+		}{
+			{"unique", `insert into violation_child(id, name) values (1, 'b')`},
+			{"foreign key", `insert into violation_child(id, parent_id, name) values (2, 42, 'b')`},
+			{"check", `insert into violation_child(id, name, n) values (2, 'b', -1)`},
+			{"not null", `insert into violation_child(id) values (2)`},
+		} {
+			_, err := conn.Exec(ctx, tt.sql)
+			require.Error(t, err, tt.violation)
+
+			for name, predicate := range predicates {
+				require.Equalf(t, name == tt.violation, predicate(err), "%s violation checked with %s predicate", tt.violation, name)
+				require.Equalf(t, name == tt.violation, predicate(fmt.Errorf("wrapped: %w", err)), "wrapped %s violation checked with %s predicate", tt.violation, name)
+			}
+		}
+
+		for name, predicate := range predicates {
+			require.Falsef(t, predicate(nil), "%s predicate with nil", name)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package pgx
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/internal/sanitize"
+)
+
+// ExpandSliceArgs can be used as the first argument to a query method. It contains the query arguments. Each '$n...'
+// placeholder is replaced with a comma separated list of placeholders, one for each element of the slice in argument n.
+// Plain '$n' placeholders are passed through as a single argument. All placeholders are renumbered to match the
+// flattened arguments.
+//
+// For example, the following two queries are equivalent:
+//
+//	conn.Query(ctx, "select * from widgets where id in ($1...) and bar = $2", pgx.ExpandSliceArgs{[]int32{1, 2, 3}, 4})
+//	conn.Query(ctx, "select * from widgets where id in ($1, $2, $3) and bar = $4", 1, 2, 3, 4)
+//
+// An empty slice cannot be expanded as PostgreSQL does not allow an empty IN list.
+type ExpandSliceArgs []any
+
+// RewriteQuery implements the QueryRewriter interface.
+func (ea ExpandSliceArgs) RewriteQuery(ctx context.Context, conn *Conn, sql string, args []any) (newSQL string, newArgs []any, err error) {
+	query, err := sanitize.NewQuery(sql)
+	if err != nil {
+		return "", nil, err
+	}
+
+	type placeholder struct {
+		ordinal int
+		expand  bool
+	}
+	newOrdinals := make(map[placeholder][]int, len(ea))
+	newArgs = make([]any, 0, len(ea))
+
+	sb := strings.Builder{}
+	parts := query.Parts
+	for i := 0; i < len(parts); i++ {
+		switch part := parts[i].(type) {
+		case string:
+			sb.WriteString(part)
+		case int:
+			p := placeholder{ordinal: part}
+			if i+1 < len(parts) {
+				if s, ok := parts[i+1].(string); ok && strings.HasPrefix(s, "...") {
+					p.expand = true
+					parts[i+1] = s[len("..."):]
+				}
+			}
+
+			if part < 1 || part > len(ea) {
+				return "", nil, fmt.Errorf("no argument for placeholder $%d", part)
+			}
+
+			ordinals, ok := newOrdinals[p]
+			if !ok {
+				arg := ea[part-1]
+				if p.expand {
+					v := reflect.ValueOf(arg)
+					if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+						return "", nil, fmt.Errorf("cannot expand placeholder $%d: %T is not a slice", part, arg)
+					}
+					if v.Len() == 0 {
+						return "", nil, fmt.Errorf("cannot expand placeholder $%d: slice is empty", part)
+					}
+					for j := 0; j < v.Len(); j++ {
+						newArgs = append(newArgs, v.Index(j).Interface())
+						ordinals = append(ordinals, len(newArgs))
+					}
+				} else {
+					newArgs = append(newArgs, arg)
+					ordinals = []int{len(newArgs)}
+				}
+				newOrdinals[p] = ordinals
+			}
+
+			for j, ordinal := range ordinals {
+				if j > 0 {
+					sb.WriteString(", ")
+				}
+				sb.WriteByte('$')
+				sb.WriteString(strconv.Itoa(ordinal))
+			}
+		}
+	}
+
+	return sb.String(), newArgs, nil
+}
@@ -0,0 +1,92 @@
+package pgx_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandSliceArgsRewriteQuery(t *testing.T) {
+	t.Parallel()
+
+	for i, tt := range []struct {
+		sql          string
+		args         pgx.ExpandSliceArgs
+		expectedSQL  string
+		expectedArgs []any
+	}{
+		{
+			sql:          "select * from users where id in ($1...)",
+			args:         pgx.ExpandSliceArgs{[]int32{1, 2, 3}},
+			expectedSQL:  "select * from users where id in ($1, $2, $3)",
+			expectedArgs: []any{int32(1), int32(2), int32(3)},
+		},
+		{
+			sql:          "select * from users where id in ($1...) and name = $2 and age > $3",
+			args:         pgx.ExpandSliceArgs{[]int32{1, 2}, "foo", 21},
+			expectedSQL:  "select * from users where id in ($1, $2) and name = $3 and age > $4",
+			expectedArgs: []any{int32(1), int32(2), "foo", 21},
+		},
+		{
+			sql:          "select * from users where name = $2 and id in ($1...) or parent_id in ($1...)",
+			args:         pgx.ExpandSliceArgs{[]string{"a", "b"}, "foo"},
+			expectedSQL:  "select * from users where name = $1 and id in ($2, $3) or parent_id in ($2, $3)",
+			expectedArgs: []any{"foo", "a", "b"},
+		},
+		{
+			sql:          "select * from users where id = any($1) or id in ($1...)",
+			args:         pgx.ExpandSliceArgs{[]int64{7, 8}},
+			expectedSQL:  "select * from users where id = any($1) or id in ($2, $3)",
+			expectedArgs: []any{[]int64{7, 8}, int64(7), int64(8)},
+		},
+		{
+			sql:          `select '$1...', "$1..." -- $1...` + "\n" + `from users where id in ($1...)`,
+			args:         pgx.ExpandSliceArgs{[2]int32{1, 2}},
+			expectedSQL:  `select '$1...', "$1..." -- $1...` + "\n" + `from users where id in ($1, $2)`,
+			expectedArgs: []any{int32(1), int32(2)},
+		},
+	} {
+		sql, args, err := tt.args.RewriteQuery(context.Background(), nil, tt.sql, nil)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expectedSQL, sql, "%d", i)
+		assert.Equalf(t, tt.expectedArgs, args, "%d", i)
+	}
+}
+
+func TestExpandSliceArgsRewriteQueryErrors(t *testing.T) {
+	t.Parallel()
+
+	for i, tt := range []struct {
+		sql  string
+		args pgx.ExpandSliceArgs
+	}{
+		{sql: "select * from users where id in ($1...)", args: pgx.ExpandSliceArgs{[]int32{}}},
+		{sql: "select * from users where id in ($1...)", args: pgx.ExpandSliceArgs{42}},
+		{sql: "select * from users where id in ($2...)", args: pgx.ExpandSliceArgs{[]int32{1}}},
+	} {
+		_, _, err := tt.args.RewriteQuery(context.Background(), nil, tt.sql, nil)
+		require.Errorf(t, err, "%d", i)
+	}
+}
+
+func TestExpandSliceArgsQuery(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		rows, _ := conn.Query(ctx,
+			"select n from generate_series(1, 10) n where n in ($1...) and n > $2 order by n",
+			pgx.ExpandSliceArgs{[]int32{2, 4, 6, 8}, 3},
+		)
+		numbers, err := pgx.CollectRows(rows, pgx.RowTo[int32])
+		require.NoError(t, err)
+		require.Equal(t, []int32{4, 6, 8}, numbers)
+	})
+}
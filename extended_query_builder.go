@@ -16,10 +16,20 @@ type ExtendedQueryBuilder struct {
 	paramValueBytes []byte
 	ParamFormats    []int16
 	ResultFormats   []int16
+
+	// resultFormatsByOID overrides the result format chosen by the type map for columns of the given OID.
+	resultFormatsByOID map[uint32]int16
+
+	// textResultsOnly causes all results to be requested in the text format.
+	textResultsOnly bool
 }
 
 // Build sets ParamValues, ParamFormats, and ResultFormats for use with *PgConn.ExecParams or *PgConn.ExecPrepared. If
 // sd is nil then QueryExecModeExec behavior will be used.
+//
+// Result formats are chosen per column. The binary format is only requested for columns whose type is registered in m
+// with a Codec that prefers it. All other columns, including those of unregistered types, are requested in the text
+// format so an unknown type in a query does not prevent the rest of the row from being decoded.
 func (eqb *ExtendedQueryBuilder) Build(m *pgtype.Map, sd *pgconn.StatementDescription, args []any) error {
 	eqb.reset()
 
@@ -30,24 +40,45 @@ func (eqb *ExtendedQueryBuilder) Build(m *pgtype.Map, sd *pgconn.StatementDescri
 	}
 
 	if len(sd.ParamOIDs) != len(args) {
-		return fmt.Errorf("mismatched param and argument count")
+		return fmt.Errorf("%w: expected %d, got %d", ErrWrongNumberOfArguments, len(sd.ParamOIDs), len(args))
 	}
 
 	for i := range args {
 		err := eqb.appendParam(m, sd.ParamOIDs[i], -1, args[i])
 		if err != nil {
+			if m.PlanEncode(sd.ParamOIDs[i], TextFormatCode, args[i]) == nil && m.PlanEncode(sd.ParamOIDs[i], BinaryFormatCode, args[i]) == nil {
+				return fmt.Errorf("failed to encode args[%d]: %w: cannot encode %T as %s", i, ErrUnsupportedParameterType, args[i], oidTypeName(m, sd.ParamOIDs[i]))
+			}
 			err = fmt.Errorf("failed to encode args[%d]: %v", i, err)
 			return err
 		}
 	}
 
+	// Sending no result format codes requests the text format for all columns.
+	if eqb.textResultsOnly {
+		return nil
+	}
+
 	for i := range sd.Fields {
-		eqb.appendResultFormat(m.FormatCodeForOID(sd.Fields[i].DataTypeOID))
+		oid := sd.Fields[i].DataTypeOID
+		if format, ok := eqb.resultFormatsByOID[oid]; ok {
+			eqb.appendResultFormat(format)
+		} else {
+			eqb.appendResultFormat(m.FormatCodeForOID(oid))
+		}
 	}
 
 	return nil
 }
 
+// oidTypeName returns the name of the type registered for oid in m for use in error messages.
+func oidTypeName(m *pgtype.Map, oid uint32) string {
+	if t, ok := m.TypeForOID(oid); ok {
+		return t.Name
+	}
+	return fmt.Sprintf("OID %d", oid)
+}
+
 // appendParam appends a parameter to the query. format may be -1 to automatically choose the format. If arg is nil it
 // must be an untyped nil.
 func (eqb *ExtendedQueryBuilder) appendParam(m *pgtype.Map, oid uint32, format int16, arg any) error {
@@ -117,6 +148,16 @@ func (eqb *ExtendedQueryBuilder) encodeExtendedParamValue(m *pgtype.Map, oid uin
 		return nil, nil
 	}
 
+	// The binary format of bytea is the raw bytes. Use the slice directly rather than copying it into paramValueBytes.
+	// This avoids an extra full copy of potentially very large values. The value is still copied once into the Bind
+	// message, which pgconn buffers in full before writing. Streaming parameters to the connection in chunks is not
+	// supported.
+	if formatCode == BinaryFormatCode && oid == pgtype.ByteaOID {
+		if b, ok := arg.([]byte); ok {
+			return b, nil
+		}
+	}
+
 	if eqb.paramValueBytes == nil {
 		eqb.paramValueBytes = make([]byte, 0, 128)
 	}
@@ -160,7 +201,7 @@ func (eqb *ExtendedQueryBuilder) chooseParameterFormatCode(m *pgtype.Map, oid ui
 // Given that the whole point of QueryExecModeExec is to operate without having to know the PostgreSQL types there is
 // no way to safely use binary or to specify the parameter OIDs.
 func (eqb *ExtendedQueryBuilder) appendParamsForQueryExecModeExec(m *pgtype.Map, args []any) error {
-	for _, arg := range args {
+	for i, arg := range args {
 		if arg == nil {
 			err := eqb.appendParam(m, 0, TextFormatCode, arg)
 			if err != nil {
@@ -205,7 +246,7 @@ func (eqb *ExtendedQueryBuilder) appendParamsForQueryExecModeExec(m *pgtype.Map,
 				}
 			}
 			if !ok {
-				return &unknownArgumentTypeQueryExecModeExecError{arg: arg}
+				return fmt.Errorf("failed to encode args[%d]: %w", i, &unknownArgumentTypeQueryExecModeExecError{arg: arg})
 			}
 			err := eqb.appendParam(m, dt.OID, TextFormatCode, arg)
 			if err != nil {
@@ -0,0 +1,30 @@
+package pgx_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtendedQueryBuilderResultFormatsPerColumn(t *testing.T) {
+	t.Parallel()
+
+	const unregisteredOID = 999999
+
+	sd := &pgconn.StatementDescription{
+		Fields: []pgconn.FieldDescription{
+			{Name: "n", DataTypeOID: pgtype.Int4OID},
+			{Name: "u", DataTypeOID: unregisteredOID},
+			{Name: "s", DataTypeOID: pgtype.TextOID},
+			{Name: "b", DataTypeOID: pgtype.ByteaOID},
+		},
+	}
+
+	var eqb pgx.ExtendedQueryBuilder
+	err := eqb.Build(pgtype.NewMap(), sd, nil)
+	require.NoError(t, err)
+	require.Equal(t, []int16{pgx.BinaryFormatCode, pgx.TextFormatCode, pgx.TextFormatCode, pgx.BinaryFormatCode}, eqb.ResultFormats)
+}
@@ -12,6 +12,7 @@ type LRUCache struct {
 	m            map[string]*list.Element
 	l            *list.List
 	invalidStmts []*pgconn.StatementDescription
+	onEvict      func(sd *pgconn.StatementDescription)
 }
 
 // NewLRUCache creates a new LRUCache. cap is the maximum size of the cache.
@@ -23,6 +24,12 @@ func NewLRUCache(cap int) *LRUCache {
 	}
 }
 
+// SetEvictFunc sets f to be called when a statement description is removed from the cache to make room for another.
+// It is not called for statement descriptions removed by Invalidate or InvalidateAll.
+func (c *LRUCache) SetEvictFunc(f func(sd *pgconn.StatementDescription)) {
+	c.onEvict = f
+}
+
 // Get returns the statement description for sql. Returns nil if not found.
 func (c *LRUCache) Get(key string) *pgconn.StatementDescription {
 	if el, ok := c.m[key]; ok {
@@ -105,4 +112,7 @@ func (c *LRUCache) invalidateOldest() {
 	c.invalidStmts = append(c.invalidStmts, sd)
 	delete(c.m, sd.SQL)
 	c.l.Remove(oldest)
+	if c.onEvict != nil {
+		c.onEvict(sd)
+	}
 }
@@ -1,7 +1,8 @@
-// SCRAM-SHA-256 authentication
+// SCRAM-SHA-256 and SCRAM-SHA-256-PLUS authentication
 //
 // Resources:
 //   https://tools.ietf.org/html/rfc5802
+//   https://tools.ietf.org/html/rfc5929
 //   https://tools.ietf.org/html/rfc8265
 //   https://www.postgresql.org/docs/current/sasl-authentication.html
 //
@@ -17,9 +18,13 @@ import (
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"hash"
 	"strconv"
 
 	"github.com/jackc/pgx/v5/pgproto3"
@@ -29,32 +34,40 @@ import (
 
 const clientNonceLen = 18
 
-// Perform SCRAM authentication.
-func (c *PgConn) scramAuth(serverAuthMechanisms []string) error {
-	sc, err := newScramClient(serverAuthMechanisms, c.config.Password)
+var errChannelBindingNotUsed = errors.New("channel binding required but server authenticated without channel binding")
+
+// Perform SCRAM authentication. channelBound reports whether SCRAM-SHA-256-PLUS was used.
+func (c *PgConn) scramAuth(serverAuthMechanisms []string) (channelBound bool, err error) {
+	var tlsState *tls.ConnectionState
+	if tlsConn, ok := c.conn.(*tls.Conn); ok {
+		state := tlsConn.ConnectionState()
+		tlsState = &state
+	}
+
+	sc, err := newScramClient(serverAuthMechanisms, c.config.Password, c.config.ChannelBinding, tlsState)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// Send client-first-message in a SASLInitialResponse
 	saslInitialResponse := &pgproto3.SASLInitialResponse{
-		AuthMechanism: "SCRAM-SHA-256",
+		AuthMechanism: sc.authMechanism,
 		Data:          sc.clientFirstMessage(),
 	}
 	c.frontend.Send(saslInitialResponse)
 	err = c.flushWithPotentialWriteReadDeadlock()
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// Receive server-first-message payload in an AuthenticationSASLContinue.
 	saslContinue, err := c.rxSASLContinue()
 	if err != nil {
-		return err
+		return false, err
 	}
 	err = sc.recvServerFirstMessage(saslContinue.Data)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// Send client-final-message in a SASLResponse
@@ -64,15 +77,20 @@ func (c *PgConn) scramAuth(serverAuthMechanisms []string) error {
 	c.frontend.Send(saslResponse)
 	err = c.flushWithPotentialWriteReadDeadlock()
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// Receive server-final-message payload in an AuthenticationSASLFinal.
 	saslFinal, err := c.rxSASLFinal()
 	if err != nil {
-		return err
+		return false, err
+	}
+	err = sc.recvServerFinalMessage(saslFinal.Data)
+	if err != nil {
+		return false, err
 	}
-	return sc.recvServerFinalMessage(saslFinal.Data)
+
+	return sc.channelBindingData != nil, nil
 }
 
 func (c *PgConn) rxSASLContinue() (*pgproto3.AuthenticationSASLContinue, error) {
@@ -110,6 +128,10 @@ type scramClient struct {
 	password             []byte
 	clientNonce          []byte
 
+	authMechanism      string // SCRAM-SHA-256 or SCRAM-SHA-256-PLUS
+	gs2Header          string
+	channelBindingData []byte // nil unless SCRAM-SHA-256-PLUS is used
+
 	clientFirstMessageBare []byte
 
 	serverFirstMessage   []byte
@@ -121,20 +143,50 @@ type scramClient struct {
 	authMessage    []byte
 }
 
-func newScramClient(serverAuthMechanisms []string, password string) (*scramClient, error) {
+// newScramClient creates a scramClient. channelBinding is the Config.ChannelBinding setting. tlsState is the state of
+// the TLS connection to the server or nil if TLS is not in use.
+func newScramClient(serverAuthMechanisms []string, password string, channelBinding string, tlsState *tls.ConnectionState) (*scramClient, error) {
 	sc := &scramClient{
 		serverAuthMechanisms: serverAuthMechanisms,
 	}
 
-	// Ensure server supports SCRAM-SHA-256
 	hasScramSHA256 := false
+	hasScramSHA256Plus := false
 	for _, mech := range sc.serverAuthMechanisms {
-		if mech == "SCRAM-SHA-256" {
+		switch mech {
+		case "SCRAM-SHA-256":
 			hasScramSHA256 = true
-			break
+		case "SCRAM-SHA-256-PLUS":
+			hasScramSHA256Plus = true
 		}
 	}
-	if !hasScramSHA256 {
+
+	clientSupportsChannelBinding := channelBinding != "disable" && tlsState != nil && len(tlsState.PeerCertificates) > 0
+
+	switch {
+	case clientSupportsChannelBinding && hasScramSHA256Plus:
+		cbData, err := tlsServerEndPoint(tlsState.PeerCertificates[0])
+		if err != nil {
+			return nil, err
+		}
+		sc.authMechanism = "SCRAM-SHA-256-PLUS"
+		sc.gs2Header = "p=tls-server-end-point,,"
+		sc.channelBindingData = cbData
+	case channelBinding == "require":
+		if tlsState == nil {
+			return nil, errors.New("channel binding required but connection does not use TLS")
+		}
+		return nil, errors.New("channel binding required but server does not support SCRAM-SHA-256-PLUS")
+	case hasScramSHA256:
+		sc.authMechanism = "SCRAM-SHA-256"
+		// y tells the server that the client supports channel binding but thinks the server does not. This lets the
+		// server detect a downgrade attack that removed SCRAM-SHA-256-PLUS from the list of mechanisms.
+		if clientSupportsChannelBinding {
+			sc.gs2Header = "y,,"
+		} else {
+			sc.gs2Header = "n,,"
+		}
+	default:
 		return nil, errors.New("server does not support SCRAM-SHA-256")
 	}
 
@@ -159,7 +211,7 @@ func newScramClient(serverAuthMechanisms []string, password string) (*scramClien
 
 func (sc *scramClient) clientFirstMessage() []byte {
 	sc.clientFirstMessageBare = []byte(fmt.Sprintf("n=,r=%s", sc.clientNonce))
-	return []byte(fmt.Sprintf("n,,%s", sc.clientFirstMessageBare))
+	return []byte(fmt.Sprintf("%s%s", sc.gs2Header, sc.clientFirstMessageBare))
 }
 
 func (sc *scramClient) recvServerFirstMessage(serverFirstMessage []byte) error {
@@ -218,7 +270,8 @@ func (sc *scramClient) recvServerFirstMessage(serverFirstMessage []byte) error {
 }
 
 func (sc *scramClient) clientFinalMessage() string {
-	clientFinalMessageWithoutProof := []byte(fmt.Sprintf("c=biws,r=%s", sc.clientAndServerNonce))
+	channelBinding := base64.StdEncoding.EncodeToString(append([]byte(sc.gs2Header), sc.channelBindingData...))
+	clientFinalMessageWithoutProof := []byte(fmt.Sprintf("c=%s,r=%s", channelBinding, sc.clientAndServerNonce))
 
 	sc.saltedPassword = pbkdf2.Key([]byte(sc.password), sc.salt, sc.iterations, 32, sha256.New)
 	sc.authMessage = bytes.Join([][]byte{sc.clientFirstMessageBare, sc.serverFirstMessage, clientFinalMessageWithoutProof}, []byte(","))
@@ -242,6 +295,27 @@ func (sc *scramClient) recvServerFinalMessage(serverFinalMessage []byte) error {
 	return nil
 }
 
+// tlsServerEndPoint computes the tls-server-end-point channel binding data for the server certificate cert as defined
+// in RFC 5929. It is the hash of the certificate using the hash function of the certificate's signature algorithm
+// except that MD5 and SHA-1 are replaced with SHA-256.
+func tlsServerEndPoint(cert *x509.Certificate) ([]byte, error) {
+	var h hash.Hash
+	switch cert.SignatureAlgorithm {
+	case x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1,
+		x509.SHA256WithRSA, x509.SHA256WithRSAPSS, x509.DSAWithSHA256, x509.ECDSAWithSHA256:
+		h = sha256.New()
+	case x509.SHA384WithRSA, x509.SHA384WithRSAPSS, x509.ECDSAWithSHA384:
+		h = sha512.New384()
+	case x509.SHA512WithRSA, x509.SHA512WithRSAPSS, x509.ECDSAWithSHA512:
+		h = sha512.New()
+	default:
+		return nil, fmt.Errorf("cannot compute tls-server-end-point channel binding for certificate signature algorithm %v", cert.SignatureAlgorithm)
+	}
+
+	h.Write(cert.Raw)
+	return h.Sum(nil), nil
+}
+
 func computeHMAC(key, msg []byte) []byte {
 	mac := hmac.New(sha256.New, key)
 	mac.Write(msg)
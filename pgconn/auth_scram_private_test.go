@@ -0,0 +1,151 @@
+package pgconn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCertificate(t *testing.T, key crypto.Signer) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+// scramClientFinalChannelBinding runs sc through the client-first and client-final messages and returns the c=
+// attribute of the client-final-message.
+func scramClientFinalChannelBinding(t *testing.T, sc *scramClient) string {
+	sc.clientFirstMessage()
+	err := sc.recvServerFirstMessage([]byte("r=" + string(sc.clientNonce) + "server,s=c2FsdA==,i=4096"))
+	require.NoError(t, err)
+
+	clientFinalMessage := sc.clientFinalMessage()
+	require.True(t, strings.HasPrefix(clientFinalMessage, "c="))
+	return clientFinalMessage[2:strings.IndexByte(clientFinalMessage, ',')]
+}
+
+func TestScramClientChannelBinding(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	cert := testCertificate(t, key)
+	tlsState := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	certHash := sha256.Sum256(cert.Raw)
+
+	for i, tt := range []struct {
+		mechanisms     []string
+		channelBinding string
+		tlsState       *tls.ConnectionState
+		authMechanism  string
+		gs2Header      string
+		cbind          string
+	}{
+		{
+			mechanisms:     []string{"SCRAM-SHA-256"},
+			channelBinding: "prefer",
+			authMechanism:  "SCRAM-SHA-256",
+			gs2Header:      "n,,",
+			cbind:          "biws",
+		},
+		{
+			mechanisms:     []string{"SCRAM-SHA-256-PLUS", "SCRAM-SHA-256"},
+			channelBinding: "prefer",
+			authMechanism:  "SCRAM-SHA-256",
+			gs2Header:      "n,,",
+			cbind:          "biws",
+		},
+		{
+			mechanisms:     []string{"SCRAM-SHA-256"},
+			channelBinding: "prefer",
+			tlsState:       tlsState,
+			authMechanism:  "SCRAM-SHA-256",
+			gs2Header:      "y,,",
+			cbind:          "eSws",
+		},
+		{
+			mechanisms:     []string{"SCRAM-SHA-256-PLUS", "SCRAM-SHA-256"},
+			channelBinding: "disable",
+			tlsState:       tlsState,
+			authMechanism:  "SCRAM-SHA-256",
+			gs2Header:      "n,,",
+			cbind:          "biws",
+		},
+		{
+			mechanisms:     []string{"SCRAM-SHA-256-PLUS", "SCRAM-SHA-256"},
+			channelBinding: "prefer",
+			tlsState:       tlsState,
+			authMechanism:  "SCRAM-SHA-256-PLUS",
+			gs2Header:      "p=tls-server-end-point,,",
+			cbind:          base64.StdEncoding.EncodeToString(append([]byte("p=tls-server-end-point,,"), certHash[:]...)),
+		},
+		{
+			mechanisms:     []string{"SCRAM-SHA-256-PLUS", "SCRAM-SHA-256"},
+			channelBinding: "require",
+			tlsState:       tlsState,
+			authMechanism:  "SCRAM-SHA-256-PLUS",
+			gs2Header:      "p=tls-server-end-point,,",
+			cbind:          base64.StdEncoding.EncodeToString(append([]byte("p=tls-server-end-point,,"), certHash[:]...)),
+		},
+	} {
+		sc, err := newScramClient(tt.mechanisms, "secret", tt.channelBinding, tt.tlsState)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.authMechanism, sc.authMechanism, "%d", i)
+		assert.Truef(t, strings.HasPrefix(string(sc.clientFirstMessage()), tt.gs2Header+"n=,r="), "%d", i)
+		assert.Equalf(t, tt.cbind, scramClientFinalChannelBinding(t, sc), "%d", i)
+	}
+}
+
+func TestScramClientChannelBindingRequired(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tlsState := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{testCertificate(t, key)}}
+
+	_, err = newScramClient([]string{"SCRAM-SHA-256-PLUS", "SCRAM-SHA-256"}, "secret", "require", nil)
+	require.ErrorContains(t, err, "connection does not use TLS")
+
+	_, err = newScramClient([]string{"SCRAM-SHA-256"}, "secret", "require", tlsState)
+	require.ErrorContains(t, err, "server does not support SCRAM-SHA-256-PLUS")
+}
+
+func TestTLSServerEndPoint(t *testing.T) {
+	t.Parallel()
+
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	cert := testCertificate(t, p384Key)
+	require.Equal(t, x509.ECDSAWithSHA384, cert.SignatureAlgorithm)
+
+	cbData, err := tlsServerEndPoint(cert)
+	require.NoError(t, err)
+	expected := sha512.Sum384(cert.Raw)
+	require.Equal(t, expected[:], cbData)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, err = tlsServerEndPoint(testCertificate(t, ed25519Key))
+	require.Error(t, err)
+}
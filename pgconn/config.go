@@ -26,6 +26,10 @@ type AfterConnectFunc func(ctx context.Context, pgconn *PgConn) error
 type ValidateConnectFunc func(ctx context.Context, pgconn *PgConn) error
 type GetSSLPasswordFunc func(ctx context.Context) string
 
+// defaultMaxMessageSize is the default Config.MaxMessageSize. It is the maximum size PostgreSQL allows for a single field
+// value so it should not reject any legitimate message in practice.
+const defaultMaxMessageSize = 1024 * 1024 * 1024
+
 // Config is the settings used to establish a connection to a PostgreSQL server. It must be created by [ParseConfig]. A
 // manually initialized Config will cause ConnectConfig to panic.
 type Config struct {
@@ -41,10 +45,27 @@ type Config struct {
 	BuildFrontend  BuildFrontendFunc
 	RuntimeParams  map[string]string // Run-time parameters to set on connection as session default values (e.g. search_path or application_name)
 
+	// ReadTimeout is the maximum duration to wait for a message from the server once a read has started. If it is
+	// exceeded the connection is closed and a timeout error is returned. This prevents a goroutine from blocking forever
+	// when the server or the network silently stops responding. Zero (the default) waits indefinitely.
+	ReadTimeout time.Duration
+
+	// MaxMessageSize is the maximum size in bytes of a message that will be accepted from the server. A message whose
+	// declared length exceeds it causes the connection to be closed with an error where errors.Is(ErrMessageTooLarge) is
+	// true instead of allocating a buffer for it. This guards against a corrupt or malicious length. ParseConfig sets it
+	// to 1GB, the largest value PostgreSQL allows for a single field. Zero disables the limit.
+	MaxMessageSize int
+
 	KerberosSrvName string
 	KerberosSpn     string
 	Fallbacks       []*FallbackConfig
 
+	// ChannelBinding controls the use of SCRAM-SHA-256-PLUS channel binding. "prefer" uses channel binding when the
+	// connection uses TLS and the server supports it. "require" fails the connection attempt unless the server
+	// authenticates with channel binding. "disable" never uses channel binding. ParseConfig sets it from the
+	// channel_binding setting and defaults to "prefer".
+	ChannelBinding string
+
 	// ValidateConnect is called during a connection attempt after a successful authentication with the PostgreSQL server.
 	// It can be used to validate that the server is acceptable. If this returns an error the connection is closed and the next
 	// fallback config is tried. This allows implementing high availability behavior such as libpq does with target_session_attrs.
@@ -60,6 +81,11 @@ type Config struct {
 	// OnNotification is a callback function called when a notification from the LISTEN/NOTIFY system is received.
 	OnNotification NotificationHandler
 
+	// OnParameterStatus is a callback function called when the server reports a new value for a run-time parameter
+	// after the connection has been established. It is not called for the values reported while connecting, including
+	// those caused by ValidateConnect, AfterConnect, or setup performed by a higher level package such as pgx.
+	OnParameterStatus ParameterStatusHandler
+
 	createdByParseConfig bool // Used to enforce created by ParseConfig rule.
 }
 
@@ -180,8 +206,10 @@ func NetworkAddress(host string, port uint16) (network, address string) {
 //	PGSSLROOTCERT
 //	PGSSLPASSWORD
 //	PGAPPNAME
+//	PGOPTIONS
 //	PGCONNECT_TIMEOUT
 //	PGTARGETSESSIONATTRS
+//	PGCHANNELBINDING
 //
 // See http://www.postgresql.org/docs/11/static/libpq-envars.html for details on the meaning of environment variables.
 //
@@ -208,6 +236,10 @@ func NetworkAddress(host string, port uint16) (network, address string) {
 // When multiple hosts are specified, libpq allows them to have different passwords set via the .pgpass file. pgconn
 // does not.
 //
+// Any other parameter key word is sent to the server as a run-time parameter in the startup message (see RuntimeParams).
+// This includes options, which is passed to the server exactly as given. It can set several run-time parameters at once
+// with the same command-line syntax as libpq. e.g. options='-c statement_timeout=5000 -c lock_timeout=1000'.
+//
 // In addition, ParseConfig accepts the following options:
 //
 //   - servicefile.
@@ -261,6 +293,7 @@ func ParseConfigWithOptions(connString string, options ParseConfigOptions) (*Con
 		BuildFrontend: func(r io.Reader, w io.Writer) *pgproto3.Frontend {
 			return pgproto3.NewFrontend(r, w)
 		},
+		MaxMessageSize: defaultMaxMessageSize,
 	}
 
 	if connectTimeoutSetting, present := settings["connect_timeout"]; present {
@@ -294,6 +327,7 @@ func ParseConfigWithOptions(connString string, options ParseConfigOptions) (*Con
 		"krbspn":               {},
 		"krbsrvname":           {},
 		"target_session_attrs": {},
+		"channel_binding":      {},
 		"service":              {},
 		"servicefile":          {},
 	}
@@ -370,6 +404,13 @@ func ParseConfigWithOptions(connString string, options ParseConfigOptions) (*Con
 		}
 	}
 
+	switch cb := settings["channel_binding"]; cb {
+	case "disable", "prefer", "require":
+		config.ChannelBinding = cb
+	default:
+		return nil, &parseConfigError{connString: connString, msg: fmt.Sprintf("unknown channel_binding value: %v", cb)}
+	}
+
 	switch tsa := settings["target_session_attrs"]; tsa {
 	case "read-write":
 		config.ValidateConnect = ValidateConnectTargetSessionAttrsReadWrite
@@ -413,6 +454,7 @@ func parseEnvSettings() map[string]string {
 		"PGPASSWORD":           "password",
 		"PGPASSFILE":           "passfile",
 		"PGAPPNAME":            "application_name",
+		"PGOPTIONS":            "options",
 		"PGCONNECT_TIMEOUT":    "connect_timeout",
 		"PGSSLMODE":            "sslmode",
 		"PGSSLKEY":             "sslkey",
@@ -421,6 +463,7 @@ func parseEnvSettings() map[string]string {
 		"PGSSLROOTCERT":        "sslrootcert",
 		"PGSSLPASSWORD":        "sslpassword",
 		"PGTARGETSESSIONATTRS": "target_session_attrs",
+		"PGCHANNELBINDING":     "channel_binding",
 		"PGSERVICE":            "service",
 		"PGSERVICEFILE":        "servicefile",
 	}
@@ -39,6 +39,7 @@ func defaultSettings() map[string]string {
 	}
 
 	settings["target_session_attrs"] = "any"
+	settings["channel_binding"] = "prefer"
 
 	return settings
 }
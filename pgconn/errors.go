@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -29,23 +30,24 @@ func Timeout(err error) bool {
 // http://www.postgresql.org/docs/11/static/protocol-error-fields.html for
 // detailed field description.
 type PgError struct {
-	Severity         string
-	Code             string
-	Message          string
-	Detail           string
-	Hint             string
-	Position         int32
-	InternalPosition int32
-	InternalQuery    string
-	Where            string
-	SchemaName       string
-	TableName        string
-	ColumnName       string
-	DataTypeName     string
-	ConstraintName   string
-	File             string
-	Line             int32
-	Routine          string
+	Severity            string
+	SeverityUnlocalized string
+	Code                string
+	Message             string
+	Detail              string
+	Hint                string
+	Position            int32
+	InternalPosition    int32
+	InternalQuery       string
+	Where               string
+	SchemaName          string
+	TableName           string
+	ColumnName          string
+	DataTypeName        string
+	ConstraintName      string
+	File                string
+	Line                int32
+	Routine             string
 }
 
 func (pe *PgError) Error() string {
@@ -76,8 +78,118 @@ func (e *connectError) Unwrap() error {
 	return e.err
 }
 
+// hostConnectError is the error from a single host attempted by ConnectConfig.
+type hostConnectError struct {
+	host string
+	port uint16
+	err  error
+}
+
+// appendHostConnectError appends hostErr to hostErrs. A host may be attempted more than once (e.g. with and without
+// TLS for sslmode=prefer) so an existing entry for the same host and port is replaced rather than duplicated.
+func appendHostConnectError(hostErrs []hostConnectError, hostErr hostConnectError) []hostConnectError {
+	for i := range hostErrs {
+		if hostErrs[i].host == hostErr.host && hostErrs[i].port == hostErr.port {
+			hostErrs[i] = hostErr
+			return hostErrs
+		}
+	}
+	return append(hostErrs, hostErr)
+}
+
+// multiHostConnectError is returned by ConnectConfig when more than one distinct host was attempted and none succeeded. It
+// reports the failure of each host. It unwraps to the final error so it can still be examined with errors.Is and
+// errors.As.
+type multiHostConnectError struct {
+	config   *Config
+	hostErrs []hostConnectError
+	lastErr  error
+}
+
+func (e *multiHostConnectError) Error() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "failed to connect to any host as `user=%s database=%s`:", e.config.User, e.config.Database)
+	for i, he := range e.hostErrs {
+		if i > 0 {
+			sb.WriteByte(';')
+		}
+		fmt.Fprintf(sb, " %s: ", net.JoinHostPort(he.host, strconv.FormatUint(uint64(he.port), 10)))
+
+		var cerr *connectError
+		if errors.As(he.err, &cerr) {
+			sb.WriteString(cerr.msg)
+			if cerr.err != nil {
+				fmt.Fprintf(sb, " (%s)", cerr.err.Error())
+			}
+		} else {
+			sb.WriteString(he.err.Error())
+		}
+	}
+	return sb.String()
+}
+
+func (e *multiHostConnectError) Unwrap() error {
+	return e.lastErr
+}
+
+// ErrConnBusy occurs when an operation is attempted on a connection that is already in use by another operation (e.g.
+// while the results of a previous query are still being read or when the connection is used concurrently).
+var ErrConnBusy = errors.New("conn busy")
+
+// ErrConnClosed occurs when an operation is attempted on a closed connection or when the server closes the connection
+// or the connection is reset while an operation is in progress.
+var ErrConnClosed = errors.New("conn closed")
+
+// connClosedError is returned when the connection is lost while reading from the server. It satisfies
+// errors.Is(ErrConnClosed) and unwraps to the underlying network error.
+type connClosedError struct {
+	err error
+}
+
+func (e *connClosedError) Error() string {
+	return fmt.Sprintf("conn closed: %v", e.err)
+}
+
+func (e *connClosedError) Is(target error) bool {
+	return target == ErrConnClosed
+}
+
+func (e *connClosedError) Unwrap() error {
+	return e.err
+}
+
+// ErrUnsupportedAuthMethod occurs when the server requests an authentication method that is not supported, such as
+// SSPI, or GSSAPI when no GSSAPI provider has been registered. The error message names the requested method.
+var ErrUnsupportedAuthMethod = errors.New("unsupported authentication method")
+
+// unsupportedAuthMethodError satisfies errors.Is(ErrUnsupportedAuthMethod) and unwraps to the underlying error.
+type unsupportedAuthMethodError struct {
+	method string
+	err    error
+}
+
+func (e *unsupportedAuthMethodError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("unsupported authentication method: %s", e.method)
+	}
+	return fmt.Sprintf("unsupported authentication method: %s: %v", e.method, e.err)
+}
+
+func (e *unsupportedAuthMethodError) Is(target error) bool {
+	return target == ErrUnsupportedAuthMethod
+}
+
+func (e *unsupportedAuthMethodError) Unwrap() error {
+	return e.err
+}
+
+// ErrMessageTooLarge occurs when the server sends a message larger than Config.MaxMessageSize. The connection is closed
+// as the stream can no longer be trusted.
+var ErrMessageTooLarge = errors.New("message too large")
+
 type connLockError struct {
 	status string
+	err    error
 }
 
 func (e *connLockError) SafeToRetry() bool {
@@ -88,6 +200,10 @@ func (e *connLockError) Error() string {
 	return e.status
 }
 
+func (e *connLockError) Unwrap() error {
+	return e.err
+}
+
 type parseConfigError struct {
 	connString string
 	msg        string
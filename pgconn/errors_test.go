@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -52,3 +53,47 @@ func TestConfigError(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorResponseToPgError(t *testing.T) {
+	msg := &pgproto3.ErrorResponse{
+		Severity:            "FEHLER",
+		SeverityUnlocalized: "ERROR",
+		Code:                "42601",
+		Message:             "syntax error at or near \"frm\"",
+		Detail:              "detail",
+		Hint:                "hint",
+		Position:            10,
+		InternalPosition:    3,
+		InternalQuery:       "select frm",
+		Where:               "PL/pgSQL function inline_code_block line 1 at EXECUTE",
+		SchemaName:          "public",
+		TableName:           "foo",
+		ColumnName:          "bar",
+		DataTypeName:        "int4",
+		ConstraintName:      "foo_pkey",
+		File:                "scan.l",
+		Line:                1176,
+		Routine:             "scanner_yyerror",
+	}
+
+	assert.Equal(t, &pgconn.PgError{
+		Severity:            "FEHLER",
+		SeverityUnlocalized: "ERROR",
+		Code:                "42601",
+		Message:             "syntax error at or near \"frm\"",
+		Detail:              "detail",
+		Hint:                "hint",
+		Position:            10,
+		InternalPosition:    3,
+		InternalQuery:       "select frm",
+		Where:               "PL/pgSQL function inline_code_block line 1 at EXECUTE",
+		SchemaName:          "public",
+		TableName:           "foo",
+		ColumnName:          "bar",
+		DataTypeName:        "int4",
+		ConstraintName:      "foo_pkey",
+		File:                "scan.l",
+		Line:                1176,
+		Routine:             "scanner_yyerror",
+	}, pgconn.ErrorResponseToPgError(msg))
+}
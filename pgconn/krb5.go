@@ -35,7 +35,7 @@ type GSS interface {
 
 func (c *PgConn) gssAuth() error {
 	if newGSS == nil {
-		return errors.New("kerberos error: no GSSAPI provider registered, see https://github.com/otan/gopgkrb5")
+		return &unsupportedAuthMethodError{method: "GSSAPI", err: errors.New("no GSSAPI provider registered, see https://github.com/otan/gopgkrb5")}
 	}
 	cli, err := newGSS()
 	if err != nil {
@@ -14,6 +14,8 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/jackc/pgx/v5/internal/iobufpool"
@@ -64,11 +66,26 @@ type NoticeHandler func(*PgConn, *Notice)
 // notice event.
 type NotificationHandler func(*PgConn, *Notification)
 
-// PgConn is a low-level PostgreSQL connection handle. It is not safe for concurrent usage.
+// ParameterStatusHandler is a function that is called when the server reports a change to a run-time parameter (e.g.
+// after SET TimeZone). It is called after the value returned by PgConn.ParameterStatus has been updated. The *PgConn is
+// provided so the handler is aware of the origin of the change, but it must not invoke any query method.
+type ParameterStatusHandler func(pgConn *PgConn, name, value string)
+
+// PgConn is a low-level PostgreSQL connection handle. It is not safe for concurrent usage. Attempting to start an
+// operation while another is in progress (e.g. before the results of the previous operation have been read) fails with
+// an error that wraps ErrConnBusy instead of corrupting the connection. The lock is taken atomically so an operation
+// started from another goroutine is also rejected, but the rest of the PgConn state is not synchronized and callers
+// must not rely on this for concurrent use.
 type PgConn struct {
+	// 64 bit fields accessed with atomics must be at beginning of struct to guarantee alignment for certain 32-bit
+	// architectures. See BUGS section of https://pkg.go.dev/sync/atomic.
+	bytesSent     uint64
+	bytesReceived uint64
+
 	conn              net.Conn
 	pid               uint32            // backend pid
 	secretKey         uint32            // key to use to send a cancel query message to the server
+	protocolVersion   uint32            // protocol version used for the connection
 	parameterStatuses map[string]string // parameters that have been reported by the server
 	txStatus          byte
 	frontend          *pgproto3.Frontend
@@ -78,7 +95,10 @@ type PgConn struct {
 
 	config *Config
 
-	status byte // One of connStatus* constants
+	status byte  // One of connStatus* constants
+	locked int32 // Set atomically by lock to prevent concurrent operations
+
+	connected bool // Set when ConnectConfig has finished including ValidateConnect and AfterConnect
 
 	bufferingReceive    bool
 	bufferingReceiveMux sync.Mutex
@@ -125,8 +145,8 @@ func ConnectWithOptions(ctx context.Context, connString string, parseConfigOptio
 //
 // If config.Fallbacks are present they will sequentially be tried in case of error establishing network connection. An
 // authentication error will terminate the chain of attempts (like libpq:
-// https://www.postgresql.org/docs/11/libpq-connect.html#LIBPQ-MULTIPLE-HOSTS) and be returned as the error. Otherwise,
-// if all attempts fail the last error is returned.
+// https://www.postgresql.org/docs/11/libpq-connect.html#LIBPQ-MULTIPLE-HOSTS). If more than one host was attempted and
+// all failed, the returned error reports the failure of each host and unwraps to the last error.
 func ConnectConfig(octx context.Context, config *Config) (pgConn *PgConn, err error) {
 	// Default values are set in ParseConfig. Enforce initial creation by ParseConfig rather than setting defaults from
 	// zero values.
@@ -155,6 +175,7 @@ func ConnectConfig(octx context.Context, config *Config) (pgConn *PgConn, err er
 
 	foundBestServer := false
 	var fallbackConfig *FallbackConfig
+	var hostErrs []hostConnectError
 	for i, fc := range fallbackConfigs {
 		// ConnectTimeout restricts the whole connection process.
 		if config.ConnectTimeout != 0 {
@@ -171,7 +192,9 @@ func ConnectConfig(octx context.Context, config *Config) (pgConn *PgConn, err er
 		if err == nil {
 			foundBestServer = true
 			break
-		} else if pgerr, ok := err.(*PgError); ok {
+		}
+		hostErrs = appendHostConnectError(hostErrs, hostConnectError{host: fc.Host, port: fc.Port, err: err})
+		if pgerr, ok := err.(*PgError); ok {
 			err = &connectError{config: config, msg: "server error", err: pgerr}
 			const ERRCODE_INVALID_PASSWORD = "28P01"                    // wrong password
 			const ERRCODE_INVALID_AUTHORIZATION_SPECIFICATION = "28000" // wrong password or bad pg_hba.conf settings
@@ -198,6 +221,9 @@ func ConnectConfig(octx context.Context, config *Config) (pgConn *PgConn, err er
 	}
 
 	if err != nil {
+		if len(hostErrs) > 1 {
+			return nil, &multiHostConnectError{config: config, hostErrs: hostErrs, lastErr: err}
+		}
 		return nil, err // no need to wrap in connectError because it will already be wrapped in all cases except PgError
 	}
 
@@ -209,6 +235,8 @@ func ConnectConfig(octx context.Context, config *Config) (pgConn *PgConn, err er
 		}
 	}
 
+	pgConn.connected = true
+
 	return pgConn, nil
 }
 
@@ -310,7 +338,11 @@ func connect(ctx context.Context, config *Config, fallbackConfig *FallbackConfig
 	)
 	pgConn.slowWriteTimer.Stop()
 	pgConn.bgReaderStarted = make(chan struct{})
-	pgConn.frontend = config.BuildFrontend(pgConn.bgReader, pgConn.conn)
+	pgConn.frontend = config.BuildFrontend(
+		&countingReader{r: pgConn.bgReader, n: &pgConn.bytesReceived},
+		&countingWriter{w: pgConn.conn, n: &pgConn.bytesSent},
+	)
+	pgConn.frontend.SetMaxBodyLen(config.MaxMessageSize)
 
 	startupMsg := pgproto3.StartupMessage{
 		ProtocolVersion: pgproto3.ProtocolVersionNumber,
@@ -332,7 +364,9 @@ func connect(ctx context.Context, config *Config, fallbackConfig *FallbackConfig
 		pgConn.conn.Close()
 		return nil, &connectError{config: config, msg: "failed to write startup message", err: normalizeTimeoutError(ctx, err)}
 	}
+	pgConn.protocolVersion = startupMsg.ProtocolVersion
 
+	var channelBound bool
 	for {
 		msg, err := pgConn.receiveMessage()
 		if err != nil {
@@ -340,6 +374,10 @@ func connect(ctx context.Context, config *Config, fallbackConfig *FallbackConfig
 			if err, ok := err.(*PgError); ok {
 				return nil, err
 			}
+			var authTypeErr *pgproto3.UnsupportedAuthTypeErr
+			if errors.As(err, &authTypeErr) {
+				return nil, &connectError{config: config, msg: "failed to authenticate", err: &unsupportedAuthMethodError{method: authTypeErr.AuthTypeName()}}
+			}
 			return nil, &connectError{config: config, msg: "failed to receive message", err: normalizeTimeoutError(ctx, err)}
 		}
 
@@ -349,13 +387,26 @@ func connect(ctx context.Context, config *Config, fallbackConfig *FallbackConfig
 			pgConn.secretKey = msg.SecretKey
 
 		case *pgproto3.AuthenticationOk:
+			// The server may skip authentication entirely (e.g. trust) so channel binding must be checked here too.
+			if config.ChannelBinding == "require" && !channelBound {
+				pgConn.conn.Close()
+				return nil, &connectError{config: config, msg: "failed to authenticate", err: errChannelBindingNotUsed}
+			}
 		case *pgproto3.AuthenticationCleartextPassword:
+			if config.ChannelBinding == "require" {
+				pgConn.conn.Close()
+				return nil, &connectError{config: config, msg: "failed to authenticate", err: errChannelBindingNotUsed}
+			}
 			err = pgConn.txPasswordMessage(pgConn.config.Password)
 			if err != nil {
 				pgConn.conn.Close()
 				return nil, &connectError{config: config, msg: "failed to write password message", err: err}
 			}
 		case *pgproto3.AuthenticationMD5Password:
+			if config.ChannelBinding == "require" {
+				pgConn.conn.Close()
+				return nil, &connectError{config: config, msg: "failed to authenticate", err: errChannelBindingNotUsed}
+			}
 			digestedPassword := "md5" + hexMD5(hexMD5(pgConn.config.Password+pgConn.config.User)+string(msg.Salt[:]))
 			err = pgConn.txPasswordMessage(digestedPassword)
 			if err != nil {
@@ -363,12 +414,16 @@ func connect(ctx context.Context, config *Config, fallbackConfig *FallbackConfig
 				return nil, &connectError{config: config, msg: "failed to write password message", err: err}
 			}
 		case *pgproto3.AuthenticationSASL:
-			err = pgConn.scramAuth(msg.AuthMechanisms)
+			channelBound, err = pgConn.scramAuth(msg.AuthMechanisms)
 			if err != nil {
 				pgConn.conn.Close()
 				return nil, &connectError{config: config, msg: "failed SASL auth", err: err}
 			}
 		case *pgproto3.AuthenticationGSS:
+			if config.ChannelBinding == "require" {
+				pgConn.conn.Close()
+				return nil, &connectError{config: config, msg: "failed to authenticate", err: errChannelBindingNotUsed}
+			}
 			err = pgConn.gssAuth()
 			if err != nil {
 				pgConn.conn.Close()
@@ -413,6 +468,30 @@ func newContextWatcher(conn net.Conn) *ctxwatch.ContextWatcher {
 	)
 }
 
+// countingReader wraps an io.Reader and atomically adds the number of bytes read to n.
+type countingReader struct {
+	r io.Reader
+	n *uint64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	atomic.AddUint64(cr.n, uint64(n))
+	return n, err
+}
+
+// countingWriter wraps an io.Writer and atomically adds the number of bytes written to n.
+type countingWriter struct {
+	w io.Writer
+	n *uint64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	atomic.AddUint64(cw.n, uint64(n))
+	return n, err
+}
+
 func startTLS(conn net.Conn, tlsConfig *tls.Config) (net.Conn, error) {
 	err := binary.Write(conn, binary.BigEndian, []int32{8, 80877103})
 	if err != nil {
@@ -452,7 +531,7 @@ func (pgConn *PgConn) signalMessage() chan struct{} {
 
 	ch := make(chan struct{})
 	go func() {
-		pgConn.bufferingReceiveMsg, pgConn.bufferingReceiveErr = pgConn.frontend.Receive()
+		pgConn.bufferingReceiveMsg, pgConn.bufferingReceiveErr = pgConn.frontendReceive()
 		pgConn.bufferingReceiveMux.Unlock()
 		close(ch)
 	}()
@@ -512,10 +591,10 @@ func (pgConn *PgConn) peekMessage() (pgproto3.BackendMessage, error) {
 		// If a timeout error happened in the background try the read again.
 		var netErr net.Error
 		if errors.As(err, &netErr) && netErr.Timeout() {
-			msg, err = pgConn.frontend.Receive()
+			msg, err = pgConn.frontendReceive()
 		}
 	} else {
-		msg, err = pgConn.frontend.Receive()
+		msg, err = pgConn.frontendReceive()
 	}
 
 	if err != nil {
@@ -526,6 +605,13 @@ func (pgConn *PgConn) peekMessage() (pgproto3.BackendMessage, error) {
 			pgConn.asyncClose()
 		}
 
+		var bodyLenErr *pgproto3.ExceededMaxBodyLenErr
+		if errors.As(err, &bodyLenErr) {
+			err = fmt.Errorf("%w: %v", ErrMessageTooLarge, err)
+		} else if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) {
+			err = &connClosedError{err: err}
+		}
+
 		return nil, err
 	}
 
@@ -533,6 +619,30 @@ func (pgConn *PgConn) peekMessage() (pgproto3.BackendMessage, error) {
 	return msg, nil
 }
 
+// frontendReceive receives a message from the frontend. If Config.ReadTimeout is set and a message is not received
+// within that time then a timeout error is returned. The read deadline is only ever moved into the past so it cannot
+// interfere with context cancellation.
+func (pgConn *PgConn) frontendReceive() (pgproto3.BackendMessage, error) {
+	readTimeout := pgConn.config.ReadTimeout
+	if readTimeout <= 0 {
+		return pgConn.frontend.Receive()
+	}
+
+	timer := time.AfterFunc(readTimeout, func() {
+		pgConn.conn.SetReadDeadline(time.Date(1, 1, 1, 1, 1, 1, 1, time.UTC))
+	})
+
+	msg, err := pgConn.frontend.Receive()
+
+	// Once the timer has fired the read deadline is in the past so the connection is unusable even if a message was
+	// received.
+	if !timer.Stop() {
+		return nil, &errTimeout{err: fmt.Errorf("no message received within read timeout of %v", readTimeout)}
+	}
+
+	return msg, err
+}
+
 // receiveMessage receives a message without setting up context cancellation
 func (pgConn *PgConn) receiveMessage() (pgproto3.BackendMessage, error) {
 	msg, err := pgConn.peekMessage()
@@ -546,6 +656,10 @@ func (pgConn *PgConn) receiveMessage() (pgproto3.BackendMessage, error) {
 		pgConn.txStatus = msg.TxStatus
 	case *pgproto3.ParameterStatus:
 		pgConn.parameterStatuses[msg.Name] = msg.Value
+		// The values sent while connecting, including in response to ValidateConnect and AfterConnect, are not changes.
+		if pgConn.config.OnParameterStatus != nil && pgConn.connected {
+			pgConn.config.OnParameterStatus(pgConn, msg.Name, msg.Value)
+		}
 	case *pgproto3.ErrorResponse:
 		if msg.Severity == "FATAL" {
 			pgConn.status = connStatusClosed
@@ -572,11 +686,25 @@ func (pgConn *PgConn) Conn() net.Conn {
 	return pgConn.conn
 }
 
-// PID returns the backend PID.
+// PID returns the backend PID. It is received in the BackendKeyData message at startup. It matches the pid column of
+// pg_stat_activity and the %p escape in the server log_line_prefix so it can be used to correlate a connection with
+// server side activity. It is also used by CancelRequest.
 func (pgConn *PgConn) PID() uint32 {
 	return pgConn.pid
 }
 
+// BytesSent returns the total number of bytes written to the server over the life of the connection. It is safe to
+// call concurrently with other methods.
+func (pgConn *PgConn) BytesSent() uint64 {
+	return atomic.LoadUint64(&pgConn.bytesSent)
+}
+
+// BytesReceived returns the total number of bytes read from the server over the life of the connection. It is safe to
+// call concurrently with other methods.
+func (pgConn *PgConn) BytesReceived() uint64 {
+	return atomic.LoadUint64(&pgConn.bytesReceived)
+}
+
 // TxStatus returns the current TxStatus as reported by the server in the ReadyForQuery message.
 //
 // Possible return values:
@@ -595,6 +723,13 @@ func (pgConn *PgConn) SecretKey() uint32 {
 	return pgConn.secretKey
 }
 
+// ProtocolVersion returns the version of the frontend/backend protocol used for the connection. The major version is in
+// the high 16 bits and the minor version is in the low 16 bits (e.g. 196608 is 3.0). Every server that speaks protocol
+// 3.0 supports the extended query protocol and the binary COPY format.
+func (pgConn *PgConn) ProtocolVersion() uint32 {
+	return pgConn.protocolVersion
+}
+
 // Frontend returns the underlying *pgproto3.Frontend. This rarely necessary.
 func (pgConn *PgConn) Frontend() *pgproto3.Frontend {
 	return pgConn.frontend
@@ -608,6 +743,7 @@ func (pgConn *PgConn) Close(ctx context.Context) error {
 		return nil
 	}
 	pgConn.status = connStatusClosed
+	pgConn.releaseLock()
 
 	defer close(pgConn.cleanupDone)
 	defer pgConn.conn.Close()
@@ -642,6 +778,7 @@ func (pgConn *PgConn) asyncClose() {
 		return
 	}
 	pgConn.status = connStatusClosed
+	pgConn.releaseLock()
 
 	go func() {
 		defer close(pgConn.cleanupDone)
@@ -685,14 +822,22 @@ func (pgConn *PgConn) IsBusy() bool {
 	return pgConn.status == connStatusBusy
 }
 
-// lock locks the connection.
+// lock marks the connection as busy. It is safe to call concurrently. If another operation already holds the lock it
+// returns an error that wraps ErrConnBusy immediately rather than waiting.
 func (pgConn *PgConn) lock() error {
+	if !atomic.CompareAndSwapInt32(&pgConn.locked, 0, 1) {
+		return &connLockError{status: "conn busy", err: ErrConnBusy} // This only should be possible in case of an application bug.
+	}
+
 	switch pgConn.status {
 	case connStatusBusy:
-		return &connLockError{status: "conn busy"} // This only should be possible in case of an application bug.
+		atomic.StoreInt32(&pgConn.locked, 0)
+		return &connLockError{status: "conn busy", err: ErrConnBusy} // This only should be possible in case of an application bug.
 	case connStatusClosed:
-		return &connLockError{status: "conn closed"}
+		atomic.StoreInt32(&pgConn.locked, 0)
+		return &connLockError{status: "conn closed", err: ErrConnClosed}
 	case connStatusUninitialized:
+		atomic.StoreInt32(&pgConn.locked, 0)
 		return &connLockError{status: "conn uninitialized"}
 	}
 	pgConn.status = connStatusBusy
@@ -707,6 +852,13 @@ func (pgConn *PgConn) unlock() {
 	default:
 		panic("BUG: cannot unlock unlocked connection") // This should only be possible if there is a bug in this package.
 	}
+	atomic.StoreInt32(&pgConn.locked, 0)
+}
+
+// releaseLock clears the lock taken by lock. It is called when the connection is closed because an operation that
+// fails fatally closes the connection without calling unlock. Any later lock will then report the connection closed.
+func (pgConn *PgConn) releaseLock() {
+	atomic.StoreInt32(&pgConn.locked, 0)
 }
 
 // ParameterStatus returns the value of a parameter reported by the server (e.g.
@@ -750,6 +902,28 @@ func (ct CommandTag) RowsAffected() int64 {
 	return n
 }
 
+// InsertedOID returns the OID of the inserted row. PostgreSQL only reports a non-zero OID when exactly one row was
+// inserted into a table with OIDs. As tables with OIDs are not supported since PostgreSQL 12 this is usually 0. If the
+// CommandTag was not for an INSERT then it returns 0.
+func (ct CommandTag) InsertedOID() uint32 {
+	if !ct.Insert() {
+		return 0
+	}
+
+	// INSERT oid rows
+	fields := strings.Fields(ct.s)
+	if len(fields) != 3 {
+		return 0
+	}
+
+	oid, err := strconv.ParseUint(fields[1], 10, 32)
+	if err != nil {
+		return 0
+	}
+
+	return uint32(oid)
+}
+
 func (ct CommandTag) String() string {
 	return ct.s
 }
@@ -774,6 +948,7 @@ func (ct CommandTag) Select() bool {
 	return strings.HasPrefix(ct.s, "SELECT")
 }
 
+// FieldDescription describes a column of a query result as reported by the server in the RowDescription message.
 type FieldDescription struct {
 	Name                 string
 	TableOID             uint32
@@ -781,7 +956,10 @@ type FieldDescription struct {
 	DataTypeOID          uint32
 	DataTypeSize         int16
 	TypeModifier         int32
-	Format               int16
+
+	// Format is the format code the server uses to send the values of this column: 0 for text or 1 for binary. For a
+	// statement description it is always 0 as the result formats are not known until the statement is executed.
+	Format int16
 }
 
 func (pgConn *PgConn) convertRowDescription(dst []FieldDescription, rd *pgproto3.RowDescription) []FieldDescription {
@@ -872,23 +1050,24 @@ readloop:
 // ErrorResponseToPgError converts a wire protocol error message to a *PgError.
 func ErrorResponseToPgError(msg *pgproto3.ErrorResponse) *PgError {
 	return &PgError{
-		Severity:         msg.Severity,
-		Code:             string(msg.Code),
-		Message:          string(msg.Message),
-		Detail:           string(msg.Detail),
-		Hint:             msg.Hint,
-		Position:         msg.Position,
-		InternalPosition: msg.InternalPosition,
-		InternalQuery:    string(msg.InternalQuery),
-		Where:            string(msg.Where),
-		SchemaName:       string(msg.SchemaName),
-		TableName:        string(msg.TableName),
-		ColumnName:       string(msg.ColumnName),
-		DataTypeName:     string(msg.DataTypeName),
-		ConstraintName:   msg.ConstraintName,
-		File:             string(msg.File),
-		Line:             msg.Line,
-		Routine:          string(msg.Routine),
+		Severity:            msg.Severity,
+		SeverityUnlocalized: msg.SeverityUnlocalized,
+		Code:                string(msg.Code),
+		Message:             string(msg.Message),
+		Detail:              string(msg.Detail),
+		Hint:                msg.Hint,
+		Position:            msg.Position,
+		InternalPosition:    msg.InternalPosition,
+		InternalQuery:       string(msg.InternalQuery),
+		Where:               string(msg.Where),
+		SchemaName:          string(msg.SchemaName),
+		TableName:           string(msg.TableName),
+		ColumnName:          string(msg.ColumnName),
+		DataTypeName:        string(msg.DataTypeName),
+		ConstraintName:      msg.ConstraintName,
+		File:                string(msg.File),
+		Line:                msg.Line,
+		Routine:             string(msg.Routine),
 	}
 }
 
@@ -1052,6 +1231,16 @@ func (pgConn *PgConn) Exec(ctx context.Context, sql string) *MultiResultReader {
 //
 // ResultReader must be closed before PgConn can be used again.
 func (pgConn *PgConn) ExecParams(ctx context.Context, sql string, paramValues [][]byte, paramOIDs []uint32, paramFormats []int16, resultFormats []int16) *ResultReader {
+	return pgConn.ExecParamsLimit(ctx, sql, paramValues, paramOIDs, paramFormats, resultFormats, 0)
+}
+
+// ExecParamsLimit is like ExecParams but the server returns at most maxRows rows. If maxRows is 0 all rows are
+// returned. If the query has more rows than maxRows the unnamed portal is suspended and ResultReader.PortalSuspended
+// reports true. The remaining rows can be fetched with ExecPortal.
+//
+// The unnamed portal only survives the Sync that ends ExecParamsLimit inside of a transaction block. Outside of a
+// transaction the portal is closed and the remaining rows are discarded.
+func (pgConn *PgConn) ExecParamsLimit(ctx context.Context, sql string, paramValues [][]byte, paramOIDs []uint32, paramFormats []int16, resultFormats []int16, maxRows uint32) *ResultReader {
 	result := pgConn.execExtendedPrefix(ctx, paramValues)
 	if result.closed {
 		return result
@@ -1060,7 +1249,7 @@ func (pgConn *PgConn) ExecParams(ctx context.Context, sql string, paramValues []
 	pgConn.frontend.SendParse(&pgproto3.Parse{Query: sql, ParameterOIDs: paramOIDs})
 	pgConn.frontend.SendBind(&pgproto3.Bind{ParameterFormatCodes: paramFormats, Parameters: paramValues, ResultFormatCodes: resultFormats})
 
-	pgConn.execExtendedSuffix(result)
+	pgConn.execExtendedSuffix(result, "", maxRows)
 
 	return result
 }
@@ -1078,14 +1267,53 @@ func (pgConn *PgConn) ExecParams(ctx context.Context, sql string, paramValues []
 //
 // ResultReader must be closed before PgConn can be used again.
 func (pgConn *PgConn) ExecPrepared(ctx context.Context, stmtName string, paramValues [][]byte, paramFormats []int16, resultFormats []int16) *ResultReader {
+	return pgConn.ExecPreparedLimit(ctx, stmtName, paramValues, paramFormats, resultFormats, 0)
+}
+
+// ExecPreparedLimit is like ExecPrepared but the server returns at most maxRows rows. See ExecParamsLimit for details.
+func (pgConn *PgConn) ExecPreparedLimit(ctx context.Context, stmtName string, paramValues [][]byte, paramFormats []int16, resultFormats []int16, maxRows uint32) *ResultReader {
+	return pgConn.ExecPreparedPortal(ctx, "", stmtName, paramValues, paramFormats, resultFormats, maxRows)
+}
+
+// ExecPreparedPortal is like ExecPreparedLimit but binds the statement to the portal portalName instead of the unnamed
+// portal. If portalName is empty the unnamed portal is used. Further rows are fetched with ExecPortal.
+//
+// Named portals allow more than one result to be read incrementally at the same time. The protocol places the
+// following constraints on them:
+//
+//   - A portal only lives until the end of the transaction it was created in. Each call ends with a Sync so outside of
+//     a transaction block the portal is closed before ExecPreparedPortal returns.
+//   - A named portal can not be bound again until it is closed. It can be closed with the SQL command CLOSE.
+//   - Only one ResultReader can be open at a time. Results from different portals are interleaved by alternating calls
+//     to ExecPortal, each of which must be closed before the next.
+func (pgConn *PgConn) ExecPreparedPortal(ctx context.Context, portalName, stmtName string, paramValues [][]byte, paramFormats []int16, resultFormats []int16, maxRows uint32) *ResultReader {
 	result := pgConn.execExtendedPrefix(ctx, paramValues)
 	if result.closed {
 		return result
 	}
 
-	pgConn.frontend.SendBind(&pgproto3.Bind{PreparedStatement: stmtName, ParameterFormatCodes: paramFormats, Parameters: paramValues, ResultFormatCodes: resultFormats})
+	pgConn.frontend.SendBind(&pgproto3.Bind{DestinationPortal: portalName, PreparedStatement: stmtName, ParameterFormatCodes: paramFormats, Parameters: paramValues, ResultFormatCodes: resultFormats})
+
+	pgConn.execExtendedSuffix(result, portalName, maxRows)
+
+	return result
+}
+
+// ExecPortal executes an existing portal such as one suspended by ExecParamsLimit or ExecPreparedLimit. The unnamed
+// portal is identified by the empty string. At most maxRows rows are returned. If maxRows is 0 all remaining rows are
+// returned. When rows remain after this call ResultReader.PortalSuspended reports true. When the portal has been run to
+// completion the ResultReader has the command tag of the statement.
+//
+// Portals are closed at the end of a transaction so ExecPortal is only useful inside of a transaction block.
+//
+// ResultReader must be closed before PgConn can be used again.
+func (pgConn *PgConn) ExecPortal(ctx context.Context, portalName string, maxRows uint32) *ResultReader {
+	result := pgConn.execExtendedPrefix(ctx, nil)
+	if result.closed {
+		return result
+	}
 
-	pgConn.execExtendedSuffix(result)
+	pgConn.execExtendedSuffix(result, portalName, maxRows)
 
 	return result
 }
@@ -1125,9 +1353,9 @@ func (pgConn *PgConn) execExtendedPrefix(ctx context.Context, paramValues [][]by
 	return result
 }
 
-func (pgConn *PgConn) execExtendedSuffix(result *ResultReader) {
-	pgConn.frontend.SendDescribe(&pgproto3.Describe{ObjectType: 'P'})
-	pgConn.frontend.SendExecute(&pgproto3.Execute{})
+func (pgConn *PgConn) execExtendedSuffix(result *ResultReader, portalName string, maxRows uint32) {
+	pgConn.frontend.SendDescribe(&pgproto3.Describe{ObjectType: 'P', Name: portalName})
+	pgConn.frontend.SendExecute(&pgproto3.Execute{Portal: portalName, MaxRows: maxRows})
 	pgConn.frontend.SendSync(&pgproto3.Sync{})
 
 	err := pgConn.flushWithPotentialWriteReadDeadlock()
@@ -1442,6 +1670,7 @@ type ResultReader struct {
 	rowValues         [][]byte
 	commandTag        CommandTag
 	commandConcluded  bool
+	portalSuspended   bool
 	closed            bool
 	err               error
 }
@@ -1592,6 +1821,9 @@ func (rr *ResultReader) receiveMessage() (msg pgproto3.BackendMessage, err error
 		rr.concludeCommand(rr.pgConn.makeCommandTag(msg.CommandTag), nil)
 	case *pgproto3.EmptyQueryResponse:
 		rr.concludeCommand(CommandTag{}, nil)
+	case *pgproto3.PortalSuspended:
+		rr.portalSuspended = true
+		rr.concludeCommand(CommandTag{}, nil)
 	case *pgproto3.ErrorResponse:
 		rr.concludeCommand(CommandTag{}, ErrorResponseToPgError(msg))
 	}
@@ -1599,6 +1831,12 @@ func (rr *ResultReader) receiveMessage() (msg pgproto3.BackendMessage, err error
 	return msg, nil
 }
 
+// PortalSuspended returns true if the command stopped because the row limit given to ExecParamsLimit,
+// ExecPreparedLimit, or ExecPortal was reached before all rows were returned. The command tag is empty in this case.
+func (rr *ResultReader) PortalSuspended() bool {
+	return rr.portalSuspended
+}
+
 func (rr *ResultReader) concludeCommand(commandTag CommandTag, err error) {
 	// Keep the first error that is recorded. Store the error before checking if the command is already concluded to
 	// allow for receiving an error after CommandComplete but before ReadyForQuery.
@@ -1666,7 +1904,8 @@ func (pgConn *PgConn) ExecBatch(ctx context.Context, batch *Batch) *MultiResultR
 
 	pgConn.enterPotentialWriteReadDeadlock()
 	defer pgConn.exitPotentialWriteReadDeadlock()
-	_, err := pgConn.conn.Write(batch.buf)
+	n, err := pgConn.conn.Write(batch.buf)
+	atomic.AddUint64(&pgConn.bytesSent, uint64(n))
 	if err != nil {
 		multiResult.closed = true
 		multiResult.err = err
@@ -1719,6 +1958,9 @@ func (pgConn *PgConn) CheckConn() error {
 // Ping pings the server. This can be useful because a TCP connection can be broken such that a write will appear to
 // succeed even though it will never actually reach the server. Pinging immediately before sending a query reduces the
 // chances a query will be sent that fails without the client knowing whether the server received it or not.
+//
+// Ping sends a query consisting only of a comment. The server responds with an EmptyQueryResponse and ReadyForQuery
+// without planning or executing anything, so the time Ping takes is approximately one network round trip.
 func (pgConn *PgConn) Ping(ctx context.Context) error {
 	return pgConn.Exec(ctx, "-- ping").Close()
 }
@@ -1795,6 +2037,7 @@ type HijackedConn struct {
 	Conn              net.Conn
 	PID               uint32            // backend pid
 	SecretKey         uint32            // key to use to send a cancel query message to the server
+	ProtocolVersion   uint32            // protocol version used for the connection; 0 is treated as 3.0 by Construct
 	ParameterStatuses map[string]string // parameters that have been reported by the server
 	TxStatus          byte
 	Frontend          *pgproto3.Frontend
@@ -1817,6 +2060,7 @@ func (pgConn *PgConn) Hijack() (*HijackedConn, error) {
 		Conn:              pgConn.conn,
 		PID:               pgConn.pid,
 		SecretKey:         pgConn.secretKey,
+		ProtocolVersion:   pgConn.protocolVersion,
 		ParameterStatuses: pgConn.parameterStatuses,
 		TxStatus:          pgConn.txStatus,
 		Frontend:          pgConn.frontend,
@@ -1836,16 +2080,22 @@ func Construct(hc *HijackedConn) (*PgConn, error) {
 		conn:              hc.Conn,
 		pid:               hc.PID,
 		secretKey:         hc.SecretKey,
+		protocolVersion:   hc.ProtocolVersion,
 		parameterStatuses: hc.ParameterStatuses,
 		txStatus:          hc.TxStatus,
 		frontend:          hc.Frontend,
 		config:            hc.Config,
 
-		status: connStatusIdle,
+		status:    connStatusIdle,
+		connected: true,
 
 		cleanupDone: make(chan struct{}),
 	}
 
+	if pgConn.protocolVersion == 0 {
+		pgConn.protocolVersion = pgproto3.ProtocolVersionNumber
+	}
+
 	pgConn.contextWatcher = newContextWatcher(pgConn.conn)
 	pgConn.bgReader = bgreader.New(pgConn.conn)
 	pgConn.slowWriteTimer = time.AfterFunc(time.Duration(math.MaxInt64),
@@ -1856,7 +2106,11 @@ func Construct(hc *HijackedConn) (*PgConn, error) {
 	)
 	pgConn.slowWriteTimer.Stop()
 	pgConn.bgReaderStarted = make(chan struct{})
-	pgConn.frontend = hc.Config.BuildFrontend(pgConn.bgReader, pgConn.conn)
+	pgConn.frontend = hc.Config.BuildFrontend(
+		&countingReader{r: pgConn.bgReader, n: &pgConn.bytesReceived},
+		&countingWriter{w: pgConn.conn, n: &pgConn.bytesSent},
+	)
+	pgConn.frontend.SetMaxBodyLen(hc.Config.MaxMessageSize)
 
 	return pgConn, nil
 }
@@ -1867,6 +2121,11 @@ func Construct(hc *HijackedConn) (*PgConn, error) {
 // pipeline is flushed by Flush or Sync. Sync must be called after the last request is queued. Requests between
 // synchronization points are implicitly transactional unless explicit transaction control statements have been issued.
 //
+// Results are returned by GetResults in the order the requests were queued. If a request fails, the server skips all
+// remaining requests up to the next synchronization point. GetResults returns the error for the failed request and then
+// the *PipelineSync for that synchronization point; no results are returned for the skipped requests. Requests after
+// the synchronization point are processed normally.
+//
 // The context the pipeline was started with is in effect for the entire life of the Pipeline.
 //
 // For a deeper understanding of pipeline mode see the PostgreSQL documentation for the extended query protocol
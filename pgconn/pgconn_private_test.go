@@ -12,12 +12,14 @@ func TestCommandTag(t *testing.T) {
 	var tests = []struct {
 		commandTag   CommandTag
 		rowsAffected int64
+		insertedOID  uint32
 		isInsert     bool
 		isUpdate     bool
 		isDelete     bool
 		isSelect     bool
 	}{
 		{commandTag: CommandTag{s: "INSERT 0 5"}, rowsAffected: 5, isInsert: true},
+		{commandTag: CommandTag{s: "INSERT 16385 1"}, rowsAffected: 1, insertedOID: 16385, isInsert: true},
 		{commandTag: CommandTag{s: "UPDATE 0"}, rowsAffected: 0, isUpdate: true},
 		{commandTag: CommandTag{s: "UPDATE 1"}, rowsAffected: 1, isUpdate: true},
 		{commandTag: CommandTag{s: "DELETE 0"}, rowsAffected: 0, isDelete: true},
@@ -28,11 +30,19 @@ func TestCommandTag(t *testing.T) {
 		{commandTag: CommandTag{s: "CREATE TABLE"}, rowsAffected: 0},
 		{commandTag: CommandTag{s: "ALTER TABLE"}, rowsAffected: 0},
 		{commandTag: CommandTag{s: "DROP TABLE"}, rowsAffected: 0},
+		{commandTag: CommandTag{s: "INSERT 0 0"}, rowsAffected: 0, isInsert: true},
+		{commandTag: CommandTag{s: "SELECT 0"}, rowsAffected: 0, isSelect: true},
+		{commandTag: CommandTag{s: "MERGE 3"}, rowsAffected: 3},
+		{commandTag: CommandTag{s: "COPY 7"}, rowsAffected: 7},
+		{commandTag: CommandTag{s: "FETCH 2"}, rowsAffected: 2},
+		{commandTag: CommandTag{s: "MOVE 4"}, rowsAffected: 4},
+		{commandTag: CommandTag{s: ""}, rowsAffected: 0},
 	}
 
 	for i, tt := range tests {
 		ct := tt.commandTag
 		assert.Equalf(t, tt.rowsAffected, ct.RowsAffected(), "%d. %v", i, tt.commandTag)
+		assert.Equalf(t, tt.insertedOID, ct.InsertedOID(), "%d. %v", i, tt.commandTag)
 		assert.Equalf(t, tt.isInsert, ct.Insert(), "%d. %v", i, tt.commandTag)
 		assert.Equalf(t, tt.isUpdate, ct.Update(), "%d. %v", i, tt.commandTag)
 		assert.Equalf(t, tt.isDelete, ct.Delete(), "%d. %v", i, tt.commandTag)
@@ -492,6 +492,36 @@ func TestConnectWithRuntimeParams(t *testing.T) {
 	assert.Equal(t, "myschema", string(result.Rows[0][0]))
 }
 
+func TestConnectWithOptionsStartupParameter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgconn.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+
+	config.RuntimeParams["options"] = "-c statement_timeout=5000 -c search_path=my\\ schema"
+
+	conn, err := pgconn.ConnectConfig(ctx, config)
+	require.NoError(t, err)
+	defer closeConn(t, conn)
+
+	if conn.ParameterStatus("crdb_version") != "" {
+		t.Skip("Server does not support the options startup parameter")
+	}
+
+	result := conn.ExecParams(ctx, "show statement_timeout", nil, nil, nil, nil).Read()
+	require.NoError(t, result.Err)
+	require.Len(t, result.Rows, 1)
+	assert.Equal(t, "5s", string(result.Rows[0][0]))
+
+	result = conn.ExecParams(ctx, "show search_path", nil, nil, nil, nil).Read()
+	require.NoError(t, result.Err)
+	require.Len(t, result.Rows, 1)
+	assert.Equal(t, `"my schema"`, string(result.Rows[0][0]))
+}
+
 func TestConnectWithFallback(t *testing.T) {
 	t.Parallel()
 
@@ -570,6 +600,55 @@ func TestConnectWithValidateConnect(t *testing.T) {
 	assert.True(t, acceptConnCount > 1)
 }
 
+func TestConnectWithAllHostsFailingReportsEachHost(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	// Get two ports that nothing is listening on.
+	var ports []string
+	for i := 0; i < 2; i++ {
+		ln, err := net.Listen("tcp", "127.0.0.1:")
+		require.NoError(t, err)
+		_, port, _ := strings.Cut(ln.Addr().String(), ":")
+		ports = append(ports, port)
+		ln.Close()
+	}
+
+	connString := fmt.Sprintf("sslmode=disable host=127.0.0.1,127.0.0.1 port=%s,%s", ports[0], ports[1])
+	_, err := pgconn.Connect(ctx, connString)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "127.0.0.1:"+ports[0]+": dial error")
+	require.Contains(t, err.Error(), "127.0.0.1:"+ports[1]+": dial error")
+
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+}
+
+func TestConnectWithSingleHostFailingSSLModePrefer(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	// Get a port that nothing is listening on.
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	_, port, _ := strings.Cut(ln.Addr().String(), ":")
+	ln.Close()
+
+	// sslmode=prefer attempts the host with and without TLS. That is still a single host.
+	connString := fmt.Sprintf("sslmode=prefer host=127.0.0.1 port=%s", port)
+	_, err = pgconn.Connect(ctx, connString)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "failed to connect to any host")
+	require.Equal(t, 1, strings.Count(err.Error(), "dial error"))
+
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+}
+
 func TestConnectWithValidateConnectTargetSessionAttrsReadWrite(t *testing.T) {
 	t.Parallel()
 
@@ -588,6 +667,183 @@ func TestConnectWithValidateConnectTargetSessionAttrsReadWrite(t *testing.T) {
 	}
 }
 
+func TestConnectWithTargetSessionAttrsReadWriteFallsBackToWritableHost(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	// startServer starts a mock server that reports transaction_read_only as readOnly and returns its port.
+	startServer := func(readOnly string) string {
+		steps := pgmock.AcceptUnauthenticatedConnRequestSteps()
+		steps = append(steps, pgmock.ExpectAnyMessage(&pgproto3.Parse{}))
+		steps = append(steps, pgmock.ExpectAnyMessage(&pgproto3.Bind{}))
+		steps = append(steps, pgmock.ExpectAnyMessage(&pgproto3.Describe{}))
+		steps = append(steps, pgmock.ExpectAnyMessage(&pgproto3.Execute{}))
+		steps = append(steps, pgmock.ExpectAnyMessage(&pgproto3.Sync{}))
+		steps = append(steps, pgmock.SendMessage(&pgproto3.ParseComplete{}))
+		steps = append(steps, pgmock.SendMessage(&pgproto3.BindComplete{}))
+		steps = append(steps, pgmock.SendMessage(&pgproto3.RowDescription{Fields: []pgproto3.FieldDescription{
+			{Name: []byte("transaction_read_only"), DataTypeOID: 25},
+		}}))
+		steps = append(steps, pgmock.SendMessage(&pgproto3.DataRow{Values: [][]byte{[]byte(readOnly)}}))
+		steps = append(steps, pgmock.SendMessage(&pgproto3.CommandComplete{CommandTag: []byte("SHOW")}))
+		steps = append(steps, pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'I'}))
+		steps = append(steps, pgmock.WaitForClose())
+
+		script := &pgmock.Script{Steps: steps}
+
+		ln, err := net.Listen("tcp", "127.0.0.1:")
+		require.NoError(t, err)
+		t.Cleanup(func() { ln.Close() })
+
+		go func() {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			err = conn.SetDeadline(time.Now().Add(5 * time.Second))
+			if err != nil {
+				return
+			}
+
+			script.Run(pgproto3.NewBackend(conn, conn))
+		}()
+
+		_, port, _ := strings.Cut(ln.Addr().String(), ":")
+		return port
+	}
+
+	readOnlyPort := startServer("on")
+	writablePort := startServer("off")
+
+	connString := fmt.Sprintf("sslmode=disable host=127.0.0.1,127.0.0.1 port=%s,%s target_session_attrs=read-write", readOnlyPort, writablePort)
+	conn, err := pgconn.Connect(ctx, connString)
+	require.NoError(t, err)
+	defer closeConn(t, conn)
+
+	_, port, _ := strings.Cut(conn.Conn().RemoteAddr().String(), ":")
+	require.Equal(t, writablePort, port)
+}
+
+func TestConnectUnsupportedAuthMethod(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		err = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if err != nil {
+			return
+		}
+
+		_, err = pgproto3.NewBackend(conn, conn).ReceiveStartupMessage()
+		if err != nil {
+			return
+		}
+
+		// pgproto3 has no message type for AuthenticationSSPI so write it directly.
+		conn.Write([]byte{'R', 0, 0, 0, 8, 0, 0, 0, pgproto3.AuthTypeSSPI})
+		conn.Read(make([]byte, 1))
+	}()
+
+	_, port, _ := strings.Cut(ln.Addr().String(), ":")
+	conn, err := pgconn.Connect(ctx, fmt.Sprintf("sslmode=disable host=127.0.0.1 port=%s", port))
+	if !assert.Error(t, err) {
+		conn.Close(ctx)
+		return
+	}
+	require.ErrorIs(t, err, pgconn.ErrUnsupportedAuthMethod)
+	require.Contains(t, err.Error(), "SSPI")
+}
+
+func TestConnectChannelBindingRequiredRejectsOtherAuthentication(t *testing.T) {
+	t.Parallel()
+
+	for _, authMsg := range []pgproto3.BackendMessage{
+		&pgproto3.AuthenticationOk{},
+		&pgproto3.AuthenticationCleartextPassword{},
+		&pgproto3.AuthenticationMD5Password{Salt: [4]byte{1, 2, 3, 4}},
+	} {
+		func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+			defer cancel()
+
+			ln, err := net.Listen("tcp", "127.0.0.1:")
+			require.NoError(t, err)
+			defer ln.Close()
+
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				err = conn.SetDeadline(time.Now().Add(5 * time.Second))
+				if err != nil {
+					return
+				}
+
+				backend := pgproto3.NewBackend(conn, conn)
+				_, err = backend.ReceiveStartupMessage()
+				if err != nil {
+					return
+				}
+
+				backend.Send(authMsg)
+				backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+				err = backend.Flush()
+				if err != nil {
+					return
+				}
+				conn.Read(make([]byte, 1))
+			}()
+
+			_, port, _ := strings.Cut(ln.Addr().String(), ":")
+			conn, err := pgconn.Connect(ctx, fmt.Sprintf("sslmode=disable channel_binding=require host=127.0.0.1 port=%s", port))
+			if !assert.Errorf(t, err, "%T", authMsg) {
+				conn.Close(ctx)
+				return
+			}
+			require.ErrorContainsf(t, err, "channel binding required", "%T", authMsg)
+		}()
+	}
+}
+
+func TestConnectSCRAMChannelBinding(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	connString := os.Getenv("PGX_TEST_SCRAM_CHANNEL_BINDING_CONN_STRING")
+	if connString == "" {
+		t.Skipf("Skipping due to missing environment variable %v", "PGX_TEST_SCRAM_CHANNEL_BINDING_CONN_STRING")
+	}
+
+	config, err := pgconn.ParseConfig(connString)
+	require.NoError(t, err)
+	config.ChannelBinding = "require"
+
+	conn, err := pgconn.ConnectConfig(ctx, config)
+	require.NoError(t, err)
+	closeConn(t, conn)
+}
+
 func TestConnectWithAfterConnect(t *testing.T) {
 	t.Parallel()
 
@@ -683,6 +939,32 @@ func TestConnExec(t *testing.T) {
 	ensureConnValid(t, pgConn)
 }
 
+func TestConnBytesSentAndReceived(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgConn, err := pgconn.Connect(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	defer closeConn(t, pgConn)
+
+	sentBefore := pgConn.BytesSent()
+	receivedBefore := pgConn.BytesReceived()
+	assert.NotZero(t, sentBefore)
+	assert.NotZero(t, receivedBefore)
+
+	sql := "select 'Hello, world'"
+	_, err = pgConn.Exec(ctx, sql).ReadAll()
+	require.NoError(t, err)
+
+	// Query message is 1 byte type + 4 byte length + null terminated query string.
+	assert.EqualValues(t, sentBefore+uint64(1+4+len(sql)+1), pgConn.BytesSent())
+	assert.Greater(t, pgConn.BytesReceived(), receivedBefore+uint64(len("Hello, world")))
+
+	ensureConnValid(t, pgConn)
+}
+
 func TestConnExecEmpty(t *testing.T) {
 	t.Parallel()
 
@@ -835,6 +1117,36 @@ func TestConnExecDeferredError(t *testing.T) {
 	ensureConnValid(t, pgConn)
 }
 
+func TestConnExecSyntaxErrorPosition(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgConn, err := pgconn.Connect(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	defer closeConn(t, pgConn)
+
+	_, err = pgConn.Exec(ctx, "select 1 frm foo").ReadAll()
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "42601", pgErr.Code)
+	require.EqualValues(t, 14, pgErr.Position)
+
+	if pgConn.ParameterStatus("crdb_version") == "" {
+		require.Equal(t, "ERROR", pgErr.SeverityUnlocalized)
+
+		_, err = pgConn.Exec(ctx, "do $$ begin execute 'select 1 frm foo'; end $$").ReadAll()
+		require.ErrorAs(t, err, &pgErr)
+		require.Equal(t, "42601", pgErr.Code)
+		require.EqualValues(t, 14, pgErr.InternalPosition)
+		require.Equal(t, "select 1 frm foo", pgErr.InternalQuery)
+		require.Contains(t, pgErr.Where, "PL/pgSQL function inline_code_block")
+	}
+
+	ensureConnValid(t, pgConn)
+}
+
 func TestConnExecContextCanceled(t *testing.T) {
 	t.Parallel()
 
@@ -882,7 +1194,256 @@ func TestConnExecContextPrecanceled(t *testing.T) {
 	ensureConnValid(t, pgConn)
 }
 
-func TestConnExecParams(t *testing.T) {
+func TestConnExecParams(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgConn, err := pgconn.Connect(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	defer closeConn(t, pgConn)
+
+	result := pgConn.ExecParams(ctx, "select $1::text as msg", [][]byte{[]byte("Hello, world")}, nil, nil, nil)
+	require.Len(t, result.FieldDescriptions(), 1)
+	assert.Equal(t, "msg", result.FieldDescriptions()[0].Name)
+
+	rowCount := 0
+	for result.NextRow() {
+		rowCount += 1
+		assert.Equal(t, "Hello, world", string(result.Values()[0]))
+	}
+	assert.Equal(t, 1, rowCount)
+	commandTag, err := result.Close()
+	assert.Equal(t, "SELECT 1", commandTag.String())
+	assert.NoError(t, err)
+
+	ensureConnValid(t, pgConn)
+}
+
+func TestConnExecParamsLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgConn, err := pgconn.Connect(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	defer closeConn(t, pgConn)
+
+	_, err = pgConn.Exec(ctx, "begin").ReadAll()
+	require.NoError(t, err)
+
+	readPage := func(result *pgconn.ResultReader) ([]string, bool, pgconn.CommandTag) {
+		var values []string
+		for result.NextRow() {
+			values = append(values, string(result.Values()[0]))
+		}
+		commandTag, err := result.Close()
+		require.NoError(t, err)
+		return values, result.PortalSuspended(), commandTag
+	}
+
+	values, suspended, commandTag := readPage(pgConn.ExecParamsLimit(ctx, "select n from generate_series(1, 5) n", nil, nil, nil, nil, 2))
+	assert.Equal(t, []string{"1", "2"}, values)
+	assert.True(t, suspended)
+	assert.Equal(t, "", commandTag.String())
+
+	values, suspended, _ = readPage(pgConn.ExecPortal(ctx, "", 2))
+	assert.Equal(t, []string{"3", "4"}, values)
+	assert.True(t, suspended)
+
+	values, suspended, commandTag = readPage(pgConn.ExecPortal(ctx, "", 2))
+	assert.Equal(t, []string{"5"}, values)
+	assert.False(t, suspended)
+	assert.Equal(t, "SELECT 1", commandTag.String())
+
+	_, err = pgConn.Exec(ctx, "rollback").ReadAll()
+	require.NoError(t, err)
+
+	// Outside of a transaction the portal is closed by the Sync that ends the command.
+	values, suspended, _ = readPage(pgConn.ExecParamsLimit(ctx, "select n from generate_series(1, 5) n", nil, nil, nil, nil, 2))
+	assert.Equal(t, []string{"1", "2"}, values)
+	assert.True(t, suspended)
+
+	_, err = pgConn.ExecPortal(ctx, "", 2).Close()
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, "34000", pgErr.Code)
+
+	ensureConnValid(t, pgConn)
+}
+
+func TestConnExecPreparedPortal(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgConn, err := pgconn.Connect(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	defer closeConn(t, pgConn)
+
+	_, err = pgConn.Prepare(ctx, "ps1", "select n from generate_series($1::int4, $1::int4 + 2) n", nil)
+	require.NoError(t, err)
+
+	_, err = pgConn.Exec(ctx, "begin").ReadAll()
+	require.NoError(t, err)
+
+	readPage := func(result *pgconn.ResultReader) []string {
+		var values []string
+		for result.NextRow() {
+			values = append(values, string(result.Values()[0]))
+		}
+		_, err := result.Close()
+		require.NoError(t, err)
+		return values
+	}
+
+	assert.Equal(t, []string{"1"}, readPage(pgConn.ExecPreparedPortal(ctx, "a", "ps1", [][]byte{[]byte("1")}, nil, nil, 1)))
+	assert.Equal(t, []string{"10"}, readPage(pgConn.ExecPreparedPortal(ctx, "b", "ps1", [][]byte{[]byte("10")}, nil, nil, 1)))
+	assert.Equal(t, []string{"2"}, readPage(pgConn.ExecPortal(ctx, "a", 1)))
+	assert.Equal(t, []string{"11", "12"}, readPage(pgConn.ExecPortal(ctx, "b", 0)))
+	assert.Equal(t, []string{"3"}, readPage(pgConn.ExecPortal(ctx, "a", 0)))
+
+	// A named portal can not be bound again until it is closed.
+	_, err = pgConn.ExecPreparedPortal(ctx, "a", "ps1", [][]byte{[]byte("1")}, nil, nil, 1).Close()
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, "42P03", pgErr.Code)
+
+	_, err = pgConn.Exec(ctx, "rollback").ReadAll()
+	require.NoError(t, err)
+
+	ensureConnValid(t, pgConn)
+}
+
+func TestConnExecParamsLimitPortalSuspended(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	steps := pgmock.AcceptUnauthenticatedConnRequestSteps()
+	steps = append(steps, pgmock.ExpectAnyMessage(&pgproto3.Parse{}))
+	steps = append(steps, pgmock.ExpectAnyMessage(&pgproto3.Bind{}))
+	steps = append(steps, pgmock.ExpectMessage(&pgproto3.Describe{ObjectType: 'P'}))
+	steps = append(steps, pgmock.ExpectMessage(&pgproto3.Execute{MaxRows: 1}))
+	steps = append(steps, pgmock.ExpectAnyMessage(&pgproto3.Sync{}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.ParseComplete{}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.BindComplete{}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.RowDescription{Fields: []pgproto3.FieldDescription{
+		{Name: []byte("n"), DataTypeOID: 23},
+	}}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.DataRow{Values: [][]byte{[]byte("1")}}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.PortalSuspended{}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'T'}))
+	steps = append(steps, pgmock.ExpectMessage(&pgproto3.Describe{ObjectType: 'P'}))
+	steps = append(steps, pgmock.ExpectMessage(&pgproto3.Execute{MaxRows: 1}))
+	steps = append(steps, pgmock.ExpectAnyMessage(&pgproto3.Sync{}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.RowDescription{Fields: []pgproto3.FieldDescription{
+		{Name: []byte("n"), DataTypeOID: 23},
+	}}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.CommandComplete{CommandTag: []byte("SELECT 0")}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.ReadyForQuery{TxStatus: 'T'}))
+	steps = append(steps, pgmock.ExpectMessage(&pgproto3.Terminate{}))
+
+	script := &pgmock.Script{Steps: steps}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	serverErrChan := make(chan error, 1)
+	go func() {
+		defer close(serverErrChan)
+
+		conn, err := ln.Accept()
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+		defer conn.Close()
+
+		err = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+
+		err = script.Run(pgproto3.NewBackend(conn, conn))
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+	}()
+
+	_, port, _ := strings.Cut(ln.Addr().String(), ":")
+	pgConn, err := pgconn.Connect(ctx, fmt.Sprintf("sslmode=disable host=127.0.0.1 port=%s", port))
+	require.NoError(t, err)
+
+	result := pgConn.ExecParamsLimit(ctx, "select n from generate_series(1, 2) n", nil, nil, nil, nil, 1)
+	rowCount := 0
+	for result.NextRow() {
+		rowCount++
+	}
+	commandTag, err := result.Close()
+	require.NoError(t, err)
+	assert.Equal(t, 1, rowCount)
+	assert.True(t, result.PortalSuspended())
+	assert.Equal(t, "", commandTag.String())
+
+	result = pgConn.ExecPortal(ctx, "", 1)
+	require.False(t, result.NextRow())
+	commandTag, err = result.Close()
+	require.NoError(t, err)
+	assert.False(t, result.PortalSuspended())
+	assert.Equal(t, "SELECT 0", commandTag.String())
+
+	require.NoError(t, pgConn.Close(ctx))
+	require.NoError(t, <-serverErrChan)
+}
+
+func TestConnExecParamsResultFormats(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgConn, err := pgconn.Connect(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	defer closeConn(t, pgConn)
+
+	result := pgConn.ExecParams(ctx, "select 1::int4 as a, 2::int4 as b", nil, nil, nil, []int16{0, 1}).Read()
+	require.NoError(t, result.Err)
+	require.Len(t, result.FieldDescriptions, 2)
+	assert.EqualValues(t, 0, result.FieldDescriptions[0].Format)
+	assert.EqualValues(t, 1, result.FieldDescriptions[1].Format)
+	require.Len(t, result.Rows, 1)
+	assert.Equal(t, "1", string(result.Rows[0][0]))
+	assert.Equal(t, []byte{0, 0, 0, 2}, result.Rows[0][1])
+
+	ensureConnValid(t, pgConn)
+}
+
+func TestConnProtocolVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgConn, err := pgconn.Connect(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	defer closeConn(t, pgConn)
+
+	require.EqualValues(t, pgproto3.ProtocolVersionNumber, pgConn.ProtocolVersion())
+	assert.EqualValues(t, 3, pgConn.ProtocolVersion()>>16)
+	assert.EqualValues(t, 0, pgConn.ProtocolVersion()&0xffff)
+
+	ensureConnValid(t, pgConn)
+}
+
+func TestConnPID(t *testing.T) {
 	t.Parallel()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
@@ -892,19 +1453,16 @@ func TestConnExecParams(t *testing.T) {
 	require.NoError(t, err)
 	defer closeConn(t, pgConn)
 
-	result := pgConn.ExecParams(ctx, "select $1::text as msg", [][]byte{[]byte("Hello, world")}, nil, nil, nil)
-	require.Len(t, result.FieldDescriptions(), 1)
-	assert.Equal(t, "msg", result.FieldDescriptions()[0].Name)
-
-	rowCount := 0
-	for result.NextRow() {
-		rowCount += 1
-		assert.Equal(t, "Hello, world", string(result.Values()[0]))
+	if pgConn.ParameterStatus("crdb_version") != "" {
+		t.Skip("Server does not have backend processes that match pg_stat_activity")
 	}
-	assert.Equal(t, 1, rowCount)
-	commandTag, err := result.Close()
-	assert.Equal(t, "SELECT 1", commandTag.String())
-	assert.NoError(t, err)
+
+	require.NotZero(t, pgConn.PID())
+
+	result := pgConn.ExecParams(ctx, "select pid from pg_stat_activity where pid = pg_backend_pid()", nil, nil, nil, nil).Read()
+	require.NoError(t, result.Err)
+	require.Len(t, result.Rows, 1)
+	assert.Equal(t, strconv.FormatUint(uint64(pgConn.PID()), 10), string(result.Rows[0][0]))
 
 	ensureConnValid(t, pgConn)
 }
@@ -1451,6 +2009,7 @@ func TestConnLocking(t *testing.T) {
 	_, err = pgConn.Exec(ctx, "select 'Hello, world'").ReadAll()
 	assert.Error(t, err)
 	assert.Equal(t, "conn busy", err.Error())
+	assert.ErrorIs(t, err, pgconn.ErrConnBusy)
 	assert.True(t, pgconn.SafeToRetry(err))
 
 	results, err := mrr.ReadAll()
@@ -1498,6 +2057,49 @@ end$$;`)
 	ensureConnValid(t, pgConn)
 }
 
+func TestConnOnParameterStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgconn.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+
+	var changes []string
+	config.OnParameterStatus = func(c *pgconn.PgConn, name, value string) {
+		require.Equal(t, value, c.ParameterStatus(name))
+		changes = append(changes, name+"="+value)
+	}
+
+	pgConn, err := pgconn.ConnectConfig(ctx, config)
+	require.NoError(t, err)
+	defer closeConn(t, pgConn)
+
+	require.Empty(t, changes, "parameters reported during startup are not changes")
+
+	// Changes made while connecting are not reported either.
+	config.ValidateConnect = func(ctx context.Context, pgConn *pgconn.PgConn) error {
+		_, err := pgConn.Exec(ctx, "set time zone 'Europe/Paris'").ReadAll()
+		return err
+	}
+	config.AfterConnect = func(ctx context.Context, pgConn *pgconn.PgConn) error {
+		_, err := pgConn.Exec(ctx, "set time zone 'Asia/Tokyo'").ReadAll()
+		return err
+	}
+	otherConn, err := pgconn.ConnectConfig(ctx, config)
+	require.NoError(t, err)
+	defer closeConn(t, otherConn)
+	require.Equal(t, "Asia/Tokyo", otherConn.ParameterStatus("TimeZone"))
+	require.Empty(t, changes)
+
+	_, err = pgConn.Exec(ctx, "set time zone 'America/Chicago'").ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"TimeZone=America/Chicago"}, changes)
+
+	ensureConnValid(t, pgConn)
+}
+
 func TestConnOnNotification(t *testing.T) {
 	t.Parallel()
 
@@ -1823,6 +2425,55 @@ func TestConnCopyFrom(t *testing.T) {
 	ensureConnValid(t, pgConn)
 }
 
+func TestConnCopyFromWithNotices(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	var notices []*pgconn.Notice
+	config, err := pgconn.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.OnNotice = func(c *pgconn.PgConn, notice *pgconn.Notice) {
+		notices = append(notices, notice)
+	}
+
+	pgConn, err := pgconn.ConnectConfig(ctx, config)
+	require.NoError(t, err)
+	defer closeConn(t, pgConn)
+
+	if pgConn.ParameterStatus("crdb_version") != "" {
+		t.Skip("Server does not support triggers (https://github.com/cockroachdb/cockroach/issues/28296)")
+	}
+
+	_, err = pgConn.Exec(ctx, `create temporary table foo(a int4);
+
+create function pg_temp.notice_foo() returns trigger as $$
+begin
+	raise notice 'copying %', new.a;
+	return new;
+end
+$$ language plpgsql;
+
+create trigger notice_foo before insert on foo for each row execute procedure pg_temp.notice_foo();`).ReadAll()
+	require.NoError(t, err)
+
+	srcBuf := &bytes.Buffer{}
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(srcBuf, "%d\n", i)
+	}
+
+	ct, err := pgConn.CopyFrom(ctx, srcBuf, "COPY foo FROM STDIN")
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, ct.RowsAffected())
+
+	require.Len(t, notices, 100)
+	assert.Equal(t, "copying 0", notices[0].Message)
+	assert.Equal(t, "copying 99", notices[99].Message)
+
+	ensureConnValid(t, pgConn)
+}
+
 func TestConnCopyFromBinary(t *testing.T) {
 	t.Parallel()
 
@@ -2358,6 +3009,8 @@ func TestHijackAndConstruct(t *testing.T) {
 
 	defer closeConn(t, newConn)
 
+	assert.EqualValues(t, pgproto3.ProtocolVersionNumber, newConn.ProtocolVersion())
+
 	results, err := newConn.Exec(ctx, "select 'Hello, world'").ReadAll()
 	assert.NoError(t, err)
 
@@ -2456,6 +3109,161 @@ func TestFatalErrorReceivedAfterCommandComplete(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestConnReadTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	steps := pgmock.AcceptUnauthenticatedConnRequestSteps()
+	steps = append(steps, pgmock.ExpectAnyMessage(&pgproto3.Query{}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.RowDescription{Fields: []pgproto3.FieldDescription{
+		{Name: []byte("mock")},
+	}}))
+	steps = append(steps, pgmockWaitStep(time.Second))
+
+	script := &pgmock.Script{Steps: steps}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	serverErrChan := make(chan error, 1)
+	go func() {
+		defer close(serverErrChan)
+
+		conn, err := ln.Accept()
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+		defer conn.Close()
+
+		err = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+
+		err = script.Run(pgproto3.NewBackend(conn, conn))
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+	}()
+
+	host, port, _ := strings.Cut(ln.Addr().String(), ":")
+	config, err := pgconn.ParseConfig(fmt.Sprintf("sslmode=disable host=%s port=%s", host, port))
+	require.NoError(t, err)
+	config.ReadTimeout = 100 * time.Millisecond
+
+	conn, err := pgconn.ConnectConfig(ctx, config)
+	require.NoError(t, err)
+
+	tooLate := time.Now().Add(500 * time.Millisecond)
+	_, err = conn.Exec(ctx, "mocked...").ReadAll()
+	require.True(t, pgconn.Timeout(err), err)
+	require.True(t, time.Now().Before(tooLate))
+	require.True(t, conn.IsClosed())
+}
+
+func TestConnMaxMessageSize(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	steps := pgmock.AcceptUnauthenticatedConnRequestSteps()
+	steps = append(steps, pgmock.ExpectAnyMessage(&pgproto3.Query{}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.RowDescription{Fields: []pgproto3.FieldDescription{
+		{Name: []byte("mock")},
+	}}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.DataRow{Values: [][]byte{make([]byte, 2048)}}))
+	steps = append(steps, pgmockWaitStep(time.Second))
+
+	script := &pgmock.Script{Steps: steps}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		err = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if err != nil {
+			return
+		}
+
+		script.Run(pgproto3.NewBackend(conn, conn))
+	}()
+
+	host, port, _ := strings.Cut(ln.Addr().String(), ":")
+	config, err := pgconn.ParseConfig(fmt.Sprintf("sslmode=disable host=%s port=%s", host, port))
+	require.NoError(t, err)
+	config.MaxMessageSize = 1024
+
+	conn, err := pgconn.ConnectConfig(ctx, config)
+	require.NoError(t, err)
+
+	_, err = conn.Exec(ctx, "mocked...").ReadAll()
+	require.ErrorIs(t, err, pgconn.ErrMessageTooLarge)
+	require.True(t, conn.IsClosed())
+}
+
+func TestConnServerClosesConnectionDuringQuery(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	steps := pgmock.AcceptUnauthenticatedConnRequestSteps()
+	steps = append(steps, pgmock.ExpectAnyMessage(&pgproto3.Query{}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.RowDescription{Fields: []pgproto3.FieldDescription{
+		{Name: []byte("mock")},
+	}}))
+	steps = append(steps, pgmock.SendMessage(&pgproto3.DataRow{Values: [][]byte{[]byte("1")}}))
+
+	script := &pgmock.Script{Steps: steps}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		// The socket is closed as soon as the script has run, in the middle of the query.
+		defer conn.Close()
+
+		err = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if err != nil {
+			return
+		}
+
+		script.Run(pgproto3.NewBackend(conn, conn))
+	}()
+
+	host, port, _ := strings.Cut(ln.Addr().String(), ":")
+	conn, err := pgconn.Connect(ctx, fmt.Sprintf("sslmode=disable host=%s port=%s", host, port))
+	require.NoError(t, err)
+
+	_, err = conn.Exec(ctx, "mocked...").ReadAll()
+	require.ErrorIs(t, err, pgconn.ErrConnClosed)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.True(t, conn.IsClosed())
+
+	// Further use of the connection also reports ErrConnClosed.
+	_, err = conn.Exec(ctx, "select 1").ReadAll()
+	require.ErrorIs(t, err, pgconn.ErrConnClosed)
+}
+
 // https://github.com/jackc/pgconn/issues/27
 func TestConnLargeResponseWhileWritingDoesNotDeadlock(t *testing.T) {
 	t.Parallel()
@@ -2570,6 +3378,80 @@ func TestConnPing(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestConnPingSendsEmptyQuery(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	serverErrChan := make(chan error, 1)
+	go func() {
+		defer close(serverErrChan)
+
+		conn, err := ln.Accept()
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+		defer conn.Close()
+
+		err = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+
+		backend := pgproto3.NewBackend(conn, conn)
+		_, err = backend.ReceiveStartupMessage()
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+
+		backend.Send(&pgproto3.AuthenticationOk{})
+		backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+		err = backend.Flush()
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+
+		msg, err := backend.Receive()
+		if err != nil {
+			serverErrChan <- err
+			return
+		}
+		if q, ok := msg.(*pgproto3.Query); !ok || strings.TrimSpace(strings.TrimPrefix(q.String, "--")) != "ping" {
+			serverErrChan <- fmt.Errorf("unexpected message: %#v", msg)
+			return
+		}
+
+		backend.Send(&pgproto3.EmptyQueryResponse{})
+		backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+		serverErrChan <- backend.Flush()
+	}()
+
+	_, port, _ := strings.Cut(ln.Addr().String(), ":")
+	conn, err := pgconn.Connect(ctx, fmt.Sprintf("sslmode=disable host=127.0.0.1 port=%s", port))
+	require.NoError(t, err)
+	defer conn.Close(ctx)
+
+	start := time.Now()
+	err = conn.Ping(ctx)
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.NoError(t, <-serverErrChan)
+
+	// The server has closed the connection.
+	err = conn.Ping(ctx)
+	require.Error(t, err)
+	require.True(t, conn.IsClosed())
+}
+
 func TestPipelinePrepare(t *testing.T) {
 	t.Parallel()
 
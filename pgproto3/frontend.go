@@ -53,6 +53,7 @@ type Frontend struct {
 	portalSuspended                 PortalSuspended
 
 	bodyLen    int
+	maxBodyLen int // maximum length of a message body in octets; 0 means no limit
 	msgType    byte
 	partialMsg bool
 	authType   uint32
@@ -230,6 +231,9 @@ func (f *Frontend) Receive() (BackendMessage, error) {
 		}
 
 		f.bodyLen = msgLength - 4
+		if f.maxBodyLen > 0 && f.bodyLen > f.maxBodyLen {
+			return nil, &ExceededMaxBodyLenErr{f.maxBodyLen, f.bodyLen}
+		}
 		f.partialMsg = true
 	}
 
@@ -338,13 +342,13 @@ func (f *Frontend) findAuthenticationMessageType(src []byte) (BackendMessage, er
 	case AuthTypeMD5Password:
 		return &f.authenticationMD5Password, nil
 	case AuthTypeSCMCreds:
-		return nil, errors.New("AuthTypeSCMCreds is unimplemented")
+		return nil, &UnsupportedAuthTypeErr{AuthType: f.authType}
 	case AuthTypeGSS:
 		return &f.authenticationGSS, nil
 	case AuthTypeGSSCont:
 		return &f.authenticationGSSContinue, nil
 	case AuthTypeSSPI:
-		return nil, errors.New("AuthTypeSSPI is unimplemented")
+		return nil, &UnsupportedAuthTypeErr{AuthType: f.authType}
 	case AuthTypeSASL:
 		return &f.authenticationSASL, nil
 	case AuthTypeSASLContinue:
@@ -352,7 +356,7 @@ func (f *Frontend) findAuthenticationMessageType(src []byte) (BackendMessage, er
 	case AuthTypeSASLFinal:
 		return &f.authenticationSASLFinal, nil
 	default:
-		return nil, fmt.Errorf("unknown authentication type: %d", f.authType)
+		return nil, &UnsupportedAuthTypeErr{AuthType: f.authType}
 	}
 }
 
@@ -365,3 +369,10 @@ func (f *Frontend) GetAuthType() uint32 {
 func (f *Frontend) ReadBufferLen() int {
 	return f.cr.wp - f.cr.rp
 }
+
+// SetMaxBodyLen sets the maximum length of a message body in octets. If a message body exceeds this length, Receive will
+// return an *ExceededMaxBodyLenErr. This protects against allocating huge buffers for a corrupt or malicious message
+// length. If maxBodyLen is 0, then no maximum is enforced.
+func (f *Frontend) SetMaxBodyLen(maxBodyLen int) {
+	f.maxBodyLen = maxBodyLen
+}
@@ -80,6 +80,39 @@ func TestFrontendReceiveUnexpectedEOF(t *testing.T) {
 	assert.Equal(t, io.ErrUnexpectedEOF, err)
 }
 
+func TestFrontendReceiveExceededMaxBodyLen(t *testing.T) {
+	t.Parallel()
+
+	server := &interruptReader{}
+	server.push([]byte{'D', 0, 0, 10, 4})
+
+	frontend := pgproto3.NewFrontend(server, nil)
+	frontend.SetMaxBodyLen(1024)
+
+	msg, err := frontend.Receive()
+	assert.Nil(t, msg)
+	var invalidBodyLenErr *pgproto3.ExceededMaxBodyLenErr
+	require.ErrorAs(t, err, &invalidBodyLenErr)
+	assert.Equal(t, 1024, invalidBodyLenErr.MaxExpectedBodyLen)
+	assert.Equal(t, 2560, invalidBodyLenErr.ActualBodyLen)
+}
+
+func TestFrontendReceiveUnsupportedAuthType(t *testing.T) {
+	t.Parallel()
+
+	server := &interruptReader{}
+	server.push([]byte{'R', 0, 0, 0, 8, 0, 0, 0, pgproto3.AuthTypeSSPI})
+
+	frontend := pgproto3.NewFrontend(server, nil)
+
+	msg, err := frontend.Receive()
+	assert.Nil(t, msg)
+	var authTypeErr *pgproto3.UnsupportedAuthTypeErr
+	require.ErrorAs(t, err, &authTypeErr)
+	assert.EqualValues(t, pgproto3.AuthTypeSSPI, authTypeErr.AuthType)
+	assert.Equal(t, "SSPI", authTypeErr.AuthTypeName())
+}
+
 func TestErrorResponse(t *testing.T) {
 	t.Parallel()
 
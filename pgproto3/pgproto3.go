@@ -44,6 +44,39 @@ func (e *invalidMessageLenErr) Error() string {
 	return fmt.Sprintf("%s body must have length of %d, but it is %d", e.messageType, e.expectedLen, e.actualLen)
 }
 
+// ExceededMaxBodyLenErr is returned by Frontend.Receive when a message body is longer than the limit set with
+// Frontend.SetMaxBodyLen.
+type ExceededMaxBodyLenErr struct {
+	MaxExpectedBodyLen int
+	ActualBodyLen      int
+}
+
+func (e *ExceededMaxBodyLenErr) Error() string {
+	return fmt.Sprintf("invalid body length: expected max %d, but got %d", e.MaxExpectedBodyLen, e.ActualBodyLen)
+}
+
+// UnsupportedAuthTypeErr is returned by Frontend.Receive when the server requests an authentication method that is
+// not implemented.
+type UnsupportedAuthTypeErr struct {
+	AuthType uint32
+}
+
+func (e *UnsupportedAuthTypeErr) Error() string {
+	return fmt.Sprintf("unsupported authentication type: %s", e.AuthTypeName())
+}
+
+// AuthTypeName returns the name of the requested authentication method.
+func (e *UnsupportedAuthTypeErr) AuthTypeName() string {
+	switch e.AuthType {
+	case AuthTypeSCMCreds:
+		return "SCM credentials"
+	case AuthTypeSSPI:
+		return "SSPI"
+	default:
+		return fmt.Sprintf("unknown (%d)", e.AuthType)
+	}
+}
+
 type invalidMessageFormatErr struct {
 	messageType string
 	details     string
@@ -117,7 +117,7 @@ func (p *encodePlanArrayCodecText) Encode(value any, buf []byte) (newBuf []byte,
 	}
 
 	var encodePlan EncodePlan
-	var lastElemType reflect.Type
+	var firstElemType reflect.Type
 	inElemBuf := make([]byte, 0, 32)
 	for i := 0; i < elementCount; i++ {
 		if i > 0 {
@@ -134,16 +134,18 @@ func (p *encodePlanArrayCodecText) Encode(value any, buf []byte) (newBuf []byte,
 		var elemBuf []byte
 		if elem != nil {
 			elemType := reflect.TypeOf(elem)
-			if lastElemType != elemType {
-				lastElemType = elemType
+			if firstElemType == nil {
+				firstElemType = elemType
 				encodePlan = p.m.PlanEncode(p.ac.ElementType.OID, TextFormatCode, elem)
 				if encodePlan == nil {
-					return nil, fmt.Errorf("unable to encode %v", array.Index(i))
+					return nil, fmt.Errorf("unable to encode array element %d %v (%T) as %s", i, elem, elem, p.ac.ElementType.Name)
 				}
+			} else if elemType != firstElemType {
+				return nil, fmt.Errorf("array element %d: %v does not match the type of previous elements %v", i, elemType, firstElemType)
 			}
 			elemBuf, err = encodePlan.Encode(elem, inElemBuf)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("array element %d: %w", i, err)
 			}
 		}
 
@@ -189,7 +191,7 @@ func (p *encodePlanArrayCodecBinary) Encode(value any, buf []byte) (newBuf []byt
 	elementCount := cardinality(dimensions)
 
 	var encodePlan EncodePlan
-	var lastElemType reflect.Type
+	var firstElemType reflect.Type
 	for i := 0; i < elementCount; i++ {
 		sp := len(buf)
 		buf = pgio.AppendInt32(buf, -1)
@@ -198,16 +200,18 @@ func (p *encodePlanArrayCodecBinary) Encode(value any, buf []byte) (newBuf []byt
 		var elemBuf []byte
 		if elem != nil {
 			elemType := reflect.TypeOf(elem)
-			if lastElemType != elemType {
-				lastElemType = elemType
+			if firstElemType == nil {
+				firstElemType = elemType
 				encodePlan = p.m.PlanEncode(p.ac.ElementType.OID, BinaryFormatCode, elem)
 				if encodePlan == nil {
-					return nil, fmt.Errorf("unable to encode %v", array.Index(i))
+					return nil, fmt.Errorf("unable to encode array element %d %v (%T) as %s", i, elem, elem, p.ac.ElementType.Name)
 				}
+			} else if elemType != firstElemType {
+				return nil, fmt.Errorf("array element %d: %v does not match the type of previous elements %v", i, elemType, firstElemType)
 			}
 			elemBuf, err = encodePlan.Encode(elem, buf)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("array element %d: %w", i, err)
 			}
 		}
 
@@ -3,6 +3,7 @@ package pgtype_test
 import (
 	"context"
 	"encoding/hex"
+	"math"
 	"reflect"
 	"strings"
 	"testing"
@@ -52,6 +53,131 @@ func TestArrayCodec(t *testing.T) {
 	})
 }
 
+func TestArrayCodecEncodeBinary(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for i, tt := range []struct {
+		oid      uint32
+		value    any
+		expected []byte
+	}{
+		{pgtype.Int4ArrayOID, []int32(nil), nil},
+		{pgtype.Int4ArrayOID, []int32{}, []byte{
+			0, 0, 0, 1, // dimensions
+			0, 0, 0, 0, // contains null
+			0, 0, 0, 23, // element OID
+			0, 0, 0, 0, 0, 0, 0, 1, // length and lower bound
+		}},
+		{pgtype.Int4ArrayOID, []int32{1, 2}, []byte{
+			0, 0, 0, 1, // dimensions
+			0, 0, 0, 0, // contains null
+			0, 0, 0, 23, // element OID
+			0, 0, 0, 2, 0, 0, 0, 1, // length and lower bound
+			0, 0, 0, 4, 0, 0, 0, 1,
+			0, 0, 0, 4, 0, 0, 0, 2,
+		}},
+		{pgtype.Int8ArrayOID, []int64{3}, []byte{
+			0, 0, 0, 1, // dimensions
+			0, 0, 0, 0, // contains null
+			0, 0, 0, 20, // element OID
+			0, 0, 0, 1, 0, 0, 0, 1, // length and lower bound
+			0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 3,
+		}},
+		{pgtype.TextArrayOID, []string{"a", "bc"}, []byte{
+			0, 0, 0, 1, // dimensions
+			0, 0, 0, 0, // contains null
+			0, 0, 0, 25, // element OID
+			0, 0, 0, 2, 0, 0, 0, 1, // length and lower bound
+			0, 0, 0, 1, 'a',
+			0, 0, 0, 2, 'b', 'c',
+		}},
+	} {
+		buf, err := m.Encode(tt.oid, pgtype.BinaryFormatCode, tt.value, nil)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, tt.expected, buf, "%d", i)
+	}
+}
+
+func TestArrayCodecEncodeSliceWithNilElements(t *testing.T) {
+	m := pgtype.NewMap()
+
+	one, three := int32(1), int32(3)
+	for i, value := range []any{
+		[]any{int32(1), nil, int32(3)},
+		[]*int32{&one, nil, &three},
+	} {
+		buf, err := m.Encode(pgtype.Int4ArrayOID, pgtype.TextFormatCode, value, nil)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, "{1,NULL,3}", string(buf), "%d", i)
+
+		buf, err = m.Encode(pgtype.Int4ArrayOID, pgtype.BinaryFormatCode, value, nil)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, []byte{
+			0, 0, 0, 1, // dimensions
+			0, 0, 0, 1, // contains null
+			0, 0, 0, 23, // element OID
+			0, 0, 0, 3, 0, 0, 0, 1, // length and lower bound
+			0, 0, 0, 4, 0, 0, 0, 1,
+			255, 255, 255, 255,
+			0, 0, 0, 4, 0, 0, 0, 3,
+		}, buf, "%d", i)
+	}
+}
+
+func TestArrayCodecEncodeSliceWithInvalidElement(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		_, err := m.Encode(pgtype.Int4ArrayOID, format, []any{nil, true}, nil)
+		require.ErrorContains(t, err, "unable to encode array element 1 true (bool) as int4")
+
+		_, err = m.Encode(pgtype.Int4ArrayOID, format, []any{int64(1), int64(1), int64(math.MaxInt64)}, nil)
+		require.ErrorContains(t, err, "array element 2: ")
+	}
+
+	_, err := m.Encode(pgtype.Int4ArrayOID, pgtype.BinaryFormatCode, []any{nil, "a"}, nil)
+	require.ErrorContains(t, err, "unable to encode array element 1 a (string) as int4")
+}
+
+func TestArrayCodecEncodeSliceWithMixedElementTypes(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		for _, tt := range []struct {
+			value    []any
+			expected string
+		}{
+			{[]any{int32(1), int64(3)}, "array element 1: int64 does not match the type of previous elements int32"},
+			{[]any{nil, int32(1), nil, int64(3)}, "array element 3: int64 does not match the type of previous elements int32"},
+			{[]any{int32(1), true}, "array element 1: bool does not match the type of previous elements int32"},
+		} {
+			_, err := m.Encode(pgtype.Int4ArrayOID, format, tt.value, nil)
+			require.ErrorContainsf(t, err, tt.expected, "%d %v", format, tt.value)
+		}
+
+		_, err := m.Encode(pgtype.TextArrayOID, format, []any{"a", pgtype.Text{String: "b", Valid: true}}, nil)
+		require.ErrorContainsf(t, err, "array element 1: pgtype.Text does not match the type of previous elements string", "%d", format)
+	}
+}
+
+func TestArrayCodecSliceArgumentWithAny(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var n int64
+		err := conn.QueryRow(ctx, "select count(*) from generate_series(1, 10) n where n = any($1)", []int32{2, 4, 11}).Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, n)
+
+		err = conn.QueryRow(ctx, "select count(*) from generate_series(1, 10) n where n = any($1)", []int64{}).Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 0, n)
+
+		var isNull bool
+		err = conn.QueryRow(ctx, "select $1::text[] is null", []string(nil)).Scan(&isNull)
+		require.NoError(t, err)
+		require.True(t, isNull)
+	})
+}
+
 func TestArrayCodecFlatArrayString(t *testing.T) {
 	testCases := []struct {
 		input []string
@@ -98,7 +98,17 @@ func (dst *Bool) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-type BoolCodec struct{}
+type BoolCodec struct {
+	// LenientScan allows bool values in the binary format to be scanned into a *string or any other TextScanner as "t"
+	// or "f". This matches the text format which can always be scanned into a string. It can ease migrating code from
+	// database/sql which converts bool values to strings. Default: false.
+	LenientScan bool
+
+	// LenientScanWords causes LenientScan to produce "true" or "false" instead of "t" or "f". This matches the output of
+	// database/sql and strconv.FormatBool rather than the PostgreSQL text format. It has no effect on values received in
+	// the text format. Default: false.
+	LenientScanWords bool
+}
 
 func (BoolCodec) FormatSupported(format int16) bool {
 	return format == TextFormatCode || format == BinaryFormatCode
@@ -199,7 +209,12 @@ func (encodePlanBoolCodecTextBool) Encode(value any, buf []byte) (newBuf []byte,
 	return buf, nil
 }
 
-func (BoolCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+func (c BoolCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	if c.LenientScan && format == BinaryFormatCode {
+		if _, ok := target.(TextScanner); ok {
+			return scanPlanBinaryBoolToTextScanner{words: c.LenientScanWords}
+		}
+	}
 
 	switch format {
 	case BinaryFormatCode:
@@ -328,6 +343,32 @@ func (scanPlanTextAnyToBoolScanner) Scan(src []byte, dst any) error {
 	return s.ScanBool(Bool{Bool: v, Valid: true})
 }
 
+type scanPlanBinaryBoolToTextScanner struct{ words bool }
+
+func (plan scanPlanBinaryBoolToTextScanner) Scan(src []byte, dst any) error {
+	s, ok := (dst).(TextScanner)
+	if !ok {
+		return ErrScanTargetTypeChanged
+	}
+
+	if src == nil {
+		return s.ScanText(Text{})
+	}
+
+	if len(src) != 1 {
+		return fmt.Errorf("invalid length for bool: %v", len(src))
+	}
+
+	b := src[0] == 1
+	if plan.words {
+		return s.ScanText(Text{String: strconv.FormatBool(b), Valid: true})
+	}
+	if b {
+		return s.ScanText(Text{String: "t", Valid: true})
+	}
+	return s.ScanText(Text{String: "f", Valid: true})
+}
+
 // https://www.postgresql.org/docs/11/datatype-boolean.html
 func planTextToBool(src []byte) (bool, error) {
 	s := string(bytes.ToLower(bytes.TrimSpace(src)))
@@ -6,6 +6,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBoolCodec(t *testing.T) {
@@ -18,6 +19,25 @@ func TestBoolCodec(t *testing.T) {
 	})
 }
 
+func TestBoolCodecEncodeBinary(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, tt := range []struct {
+		value    any
+		expected []byte
+	}{
+		{true, []byte{1}},
+		{false, []byte{0}},
+		{pgtype.Bool{Bool: true, Valid: true}, []byte{1}},
+		{pgtype.Bool{Bool: false, Valid: true}, []byte{0}},
+		{pgtype.Bool{}, nil},
+	} {
+		buf, err := m.Encode(pgtype.BoolOID, pgtype.BinaryFormatCode, tt.value, nil)
+		require.NoError(t, err)
+		require.Equalf(t, tt.expected, buf, "%v", tt.value)
+	}
+}
+
 func TestBoolMarshalJSON(t *testing.T) {
 	successfulTests := []struct {
 		source pgtype.Bool
@@ -60,3 +80,73 @@ func TestBoolUnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestBoolCodecLenientScan(t *testing.T) {
+	var s string
+
+	m := pgtype.NewMap()
+	err := m.Scan(pgtype.BoolOID, pgtype.BinaryFormatCode, []byte{1}, &s)
+	require.Error(t, err)
+
+	m.RegisterType(&pgtype.Type{Name: "bool", OID: pgtype.BoolOID, Codec: pgtype.BoolCodec{LenientScan: true}})
+
+	for i, tt := range []struct {
+		format   int16
+		src      []byte
+		expected string
+	}{
+		{pgtype.BinaryFormatCode, []byte{1}, "t"},
+		{pgtype.BinaryFormatCode, []byte{0}, "f"},
+		{pgtype.TextFormatCode, []byte("t"), "t"},
+		{pgtype.TextFormatCode, []byte("f"), "f"},
+	} {
+		err := m.Scan(pgtype.BoolOID, tt.format, tt.src, &s)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, tt.expected, s, "%d", i)
+
+		var text pgtype.Text
+		err = m.Scan(pgtype.BoolOID, tt.format, tt.src, &text)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, pgtype.Text{String: tt.expected, Valid: true}, text, "%d", i)
+	}
+
+	text := pgtype.Text{String: "foo", Valid: true}
+	err = m.Scan(pgtype.BoolOID, pgtype.BinaryFormatCode, nil, &text)
+	require.NoError(t, err)
+	require.Equal(t, pgtype.Text{}, text)
+
+	err = m.Scan(pgtype.BoolOID, pgtype.BinaryFormatCode, []byte{1, 0}, &s)
+	require.Error(t, err)
+
+	var b bool
+	err = m.Scan(pgtype.BoolOID, pgtype.BinaryFormatCode, []byte{1}, &b)
+	require.NoError(t, err)
+	require.True(t, b)
+
+	m.RegisterType(&pgtype.Type{Name: "bool", OID: pgtype.BoolOID, Codec: pgtype.BoolCodec{LenientScan: true, LenientScanWords: true}})
+
+	for i, tt := range []struct {
+		format   int16
+		src      []byte
+		expected string
+	}{
+		{pgtype.BinaryFormatCode, []byte{1}, "true"},
+		{pgtype.BinaryFormatCode, []byte{0}, "false"},
+		{pgtype.TextFormatCode, []byte("t"), "t"},
+		{pgtype.TextFormatCode, []byte("f"), "f"},
+	} {
+		err := m.Scan(pgtype.BoolOID, tt.format, tt.src, &s)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, tt.expected, s, "%d", i)
+
+		var text pgtype.Text
+		err = m.Scan(pgtype.BoolOID, tt.format, tt.src, &text)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, pgtype.Text{String: tt.expected, Valid: true}, text, "%d", i)
+	}
+
+	// Integers can already be scanned into a *string without any option.
+	err = pgtype.NewMap().Scan(pgtype.Int4OID, pgtype.BinaryFormatCode, []byte{0, 0, 0, 42}, &s)
+	require.NoError(t, err)
+	require.Equal(t, "42", s)
+}
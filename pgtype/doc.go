@@ -53,10 +53,30 @@ similar fashion to database/sql. The second is to use a pointer to a pointer.
         return err
     }
 
+Time Zones
+
+timestamptz values represent an instant in time. By default they are scanned in the local time zone. Set
+TimestamptzCodec.ScanLocation to receive them in another location. This only changes the presentation, not the
+instant. timestamp values have no time zone and are scanned as the same wall clock time in UTC by default. Set
+TimestampCodec.ScanLocation to interpret the wall clock time in another location instead. This does change the instant.
+e.g. To scan both in New York time on a connection:
+
+    loc, err := time.LoadLocation("America/New_York")
+    // handle err
+    conn.TypeMap().RegisterType(&pgtype.Type{Name: "timestamptz", OID: pgtype.TimestamptzOID, Codec: pgtype.TimestamptzCodec{ScanLocation: loc}})
+    conn.TypeMap().RegisterType(&pgtype.Type{Name: "timestamp", OID: pgtype.TimestampOID, Codec: pgtype.TimestampCodec{ScanLocation: loc}})
+
 JSON Support
 
 pgtype automatically marshals and unmarshals data from json and jsonb PostgreSQL types.
 
+XML Support
+
+xml values are scanned into and encoded from string and []byte. They are not marshaled with encoding/xml. To check
+that values are well-formed before they are sent to the server register XMLCodec with ValidateOnEncode:
+
+    conn.TypeMap().RegisterType(&pgtype.Type{Name: "xml", OID: pgtype.XMLOID, Codec: pgtype.XMLCodec{ValidateOnEncode: true}})
+
 Extending Existing PostgreSQL Type Support
 
 Generally, all Codecs will support interfaces that can be implemented to enable scanning and encoding. For example,
@@ -77,7 +97,8 @@ and enum types. However, any type created in PostgreSQL with CREATE TYPE will re
 of each new PostgreSQL type must be registered for pgtype to handle values of that type with the correct Codec.
 
 The pgx.Conn LoadType method can return a *Type for array, composite, domain, and enum types by inspecting the database
-metadata. This *Type can then be registered with Map.RegisterType.
+metadata. It also supports extension base types in the string category, such as citext, which use the text Codec. This
+*Type can then be registered with Map.RegisterType.
 
 For example, the following function could be called after a connection is established:
 
@@ -106,6 +127,9 @@ registered until its element type is registered.
 ArrayCodec implements support for arrays. If pgtype supports type T then it can easily support []T by registering an
 ArrayCodec for the appropriate PostgreSQL OID. In addition, Array[T] type can support multi-dimensional arrays.
 
+Slices can be used directly as query arguments for array parameters. e.g. A []int32 can be passed as the argument to
+"where id = any($1)". A nil slice is encoded as NULL and an empty, non-nil slice is encoded as an empty array.
+
 CompositeCodec implements support for PostgreSQL composite types. Go structs can be scanned into if the public fields of
 the struct are in the exact order and type of the PostgreSQL type or by implementing CompositeIndexScanner and
 CompositeIndexGetter.
@@ -139,6 +163,14 @@ Compatibility with database/sql
 pgtype also includes support for custom types implementing the database/sql.Scanner and database/sql/driver.Valuer
 interfaces.
 
+A driver.Valuer is only used as a parameter when the Codec for the destination type cannot encode the value directly.
+In that case Value is called and the resulting driver.Value (int64, float64, bool, []byte, string, time.Time, or nil)
+is encoded in its place. A nil driver.Value is encoded as NULL.
+
+Types implementing encoding.TextUnmarshaler or encoding.BinaryUnmarshaler can be scanned into when the Codec does not
+support them directly. The raw value in the text or binary format is passed to UnmarshalText or UnmarshalBinary. This
+allows validation or normalization of values without writing a Codec.
+
 Child Records
 
 pgtype's support for arrays and composite records can be used to load records and their children in a single query.  See
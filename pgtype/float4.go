@@ -135,7 +135,7 @@ type encodePlanTextFloat32 struct{}
 
 func (encodePlanTextFloat32) Encode(value any, buf []byte) (newBuf []byte, err error) {
 	n := value.(float32)
-	return append(buf, strconv.FormatFloat(float64(n), 'f', -1, 32)...), nil
+	return appendFloatText(buf, float64(n), 32), nil
 }
 
 type encodePlanFloat4CodecBinaryFloat64Valuer struct{}
@@ -271,7 +271,7 @@ func (scanPlanBinaryFloat4ToTextScanner) Scan(src []byte, dst any) error {
 	ui32 := int32(binary.BigEndian.Uint32(src))
 	f32 := math.Float32frombits(uint32(ui32))
 
-	return s.ScanText(Text{String: strconv.FormatFloat(float64(f32), 'f', -1, 32), Valid: true})
+	return s.ScanText(Text{String: string(appendFloatText(nil, float64(f32), 32)), Valid: true})
 }
 
 type scanPlanTextAnyToFloat32 struct{}
@@ -2,10 +2,12 @@ package pgtype_test
 
 import (
 	"context"
+	"math"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFloat4Codec(t *testing.T) {
@@ -22,6 +24,46 @@ func TestFloat4Codec(t *testing.T) {
 	})
 }
 
+func TestFloat4CodecSpecialValues(t *testing.T) {
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "float4", []pgxtest.ValueRoundTripTest{
+		{float32(math.Inf(1)), new(float32), isExpectedEq(float32(math.Inf(1)))},
+		{float32(math.Inf(-1)), new(float32), isExpectedEq(float32(math.Inf(-1)))},
+		{
+			float32(math.NaN()),
+			new(float32),
+			func(a any) bool { return math.IsNaN(float64(a.(float32))) },
+		},
+		{pgtype.Float4{Float32: float32(math.Inf(1)), Valid: true}, new(string), isExpectedEq("Infinity")},
+		{pgtype.Float4{Float32: float32(math.Inf(-1)), Valid: true}, new(string), isExpectedEq("-Infinity")},
+		{pgtype.Float4{Float32: float32(math.NaN()), Valid: true}, new(string), isExpectedEq("NaN")},
+	})
+}
+
+func TestFloat4CodecEncodeTextSpecialValues(t *testing.T) {
+	m := pgtype.NewMap()
+	for _, tt := range []struct {
+		value    float32
+		expected string
+	}{
+		{float32(math.Inf(1)), "Infinity"},
+		{float32(math.Inf(-1)), "-Infinity"},
+		{float32(math.NaN()), "NaN"},
+	} {
+		buf, err := m.Encode(pgtype.Float4OID, pgtype.TextFormatCode, tt.value, nil)
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, string(buf))
+
+		var f float32
+		err = m.Scan(pgtype.Float4OID, pgtype.TextFormatCode, buf, &f)
+		require.NoError(t, err)
+		if math.IsNaN(float64(tt.value)) {
+			require.True(t, math.IsNaN(float64(f)))
+		} else {
+			require.Equal(t, tt.value, f)
+		}
+	}
+}
+
 func TestFloat4MarshalJSON(t *testing.T) {
 	successfulTests := []struct {
 		source pgtype.Float4
@@ -139,11 +139,23 @@ func (encodePlanFloat8CodecBinaryFloat64) Encode(value any, buf []byte) (newBuf
 	return pgio.AppendUint64(buf, math.Float64bits(n)), nil
 }
 
+// appendFloatText appends the PostgreSQL text representation of f to buf. Infinite values are written as Infinity and
+// -Infinity instead of strconv's +Inf and -Inf which older servers do not accept.
+func appendFloatText(buf []byte, f float64, bitSize int) []byte {
+	switch {
+	case math.IsInf(f, 1):
+		return append(buf, "Infinity"...)
+	case math.IsInf(f, -1):
+		return append(buf, "-Infinity"...)
+	}
+	return strconv.AppendFloat(buf, f, 'f', -1, bitSize)
+}
+
 type encodePlanTextFloat64 struct{}
 
 func (encodePlanTextFloat64) Encode(value any, buf []byte) (newBuf []byte, err error) {
 	n := value.(float64)
-	return append(buf, strconv.FormatFloat(n, 'f', -1, 64)...), nil
+	return appendFloatText(buf, n, 64), nil
 }
 
 type encodePlanFloat8CodecBinaryFloat64Valuer struct{}
@@ -173,7 +185,7 @@ func (encodePlanTextFloat64Valuer) Encode(value any, buf []byte) (newBuf []byte,
 		return nil, nil
 	}
 
-	return append(buf, strconv.FormatFloat(n.Float64, 'f', -1, 64)...), nil
+	return appendFloatText(buf, n.Float64, 64), nil
 }
 
 type encodePlanFloat8CodecBinaryInt64Valuer struct{}
@@ -309,7 +321,7 @@ func (scanPlanBinaryFloat8ToTextScanner) Scan(src []byte, dst any) error {
 	ui64 := int64(binary.BigEndian.Uint64(src))
 	f64 := math.Float64frombits(uint64(ui64))
 
-	return s.ScanText(Text{String: strconv.FormatFloat(f64, 'f', -1, 64), Valid: true})
+	return s.ScanText(Text{String: string(appendFloatText(nil, f64, 64)), Valid: true})
 }
 
 type scanPlanTextAnyToFloat64 struct{}
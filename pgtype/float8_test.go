@@ -2,10 +2,12 @@ package pgtype_test
 
 import (
 	"context"
+	"math"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFloat8Codec(t *testing.T) {
@@ -22,6 +24,46 @@ func TestFloat8Codec(t *testing.T) {
 	})
 }
 
+func TestFloat8CodecSpecialValues(t *testing.T) {
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "float8", []pgxtest.ValueRoundTripTest{
+		{float64(math.Inf(1)), new(float64), isExpectedEq(float64(math.Inf(1)))},
+		{float64(math.Inf(-1)), new(float64), isExpectedEq(float64(math.Inf(-1)))},
+		{
+			float64(math.NaN()),
+			new(float64),
+			func(a any) bool { return math.IsNaN(float64(a.(float64))) },
+		},
+		{pgtype.Float8{Float64: float64(math.Inf(1)), Valid: true}, new(string), isExpectedEq("Infinity")},
+		{pgtype.Float8{Float64: float64(math.Inf(-1)), Valid: true}, new(string), isExpectedEq("-Infinity")},
+		{pgtype.Float8{Float64: float64(math.NaN()), Valid: true}, new(string), isExpectedEq("NaN")},
+	})
+}
+
+func TestFloat8CodecEncodeTextSpecialValues(t *testing.T) {
+	m := pgtype.NewMap()
+	for _, tt := range []struct {
+		value    float64
+		expected string
+	}{
+		{float64(math.Inf(1)), "Infinity"},
+		{float64(math.Inf(-1)), "-Infinity"},
+		{float64(math.NaN()), "NaN"},
+	} {
+		buf, err := m.Encode(pgtype.Float8OID, pgtype.TextFormatCode, tt.value, nil)
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, string(buf))
+
+		var f float64
+		err = m.Scan(pgtype.Float8OID, pgtype.TextFormatCode, buf, &f)
+		require.NoError(t, err)
+		if math.IsNaN(float64(tt.value)) {
+			require.True(t, math.IsNaN(float64(f)))
+		} else {
+			require.Equal(t, tt.value, f)
+		}
+	}
+}
+
 func TestFloat8MarshalJSON(t *testing.T) {
 	successfulTests := []struct {
 		source pgtype.Float8
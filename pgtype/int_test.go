@@ -3,11 +3,15 @@ package pgtype_test
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInt2Codec(t *testing.T) {
@@ -255,3 +259,49 @@ func TestInt8UnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestIntCodecScanIntoDifferentWidths(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, src := range []struct {
+		oid    uint32
+		values []int64
+	}{
+		{pgtype.Int2OID, []int64{math.MinInt16, -1, 0, math.MaxInt8 + 1, math.MaxInt16}},
+		{pgtype.Int4OID, []int64{math.MinInt32, math.MinInt16 - 1, -1, 0, math.MaxUint16 + 1, math.MaxInt32}},
+		{pgtype.Int8OID, []int64{math.MinInt64, math.MinInt32 - 1, -1, 0, math.MaxUint32 + 1, math.MaxInt64}},
+	} {
+		for _, v := range src.values {
+			for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+				buf, err := m.Encode(src.oid, format, v, nil)
+				require.NoError(t, err)
+
+				for _, dst := range []struct {
+					target any
+					min    int64
+					max    uint64
+				}{
+					{new(int8), math.MinInt8, math.MaxInt8},
+					{new(int16), math.MinInt16, math.MaxInt16},
+					{new(int32), math.MinInt32, math.MaxInt32},
+					{new(int64), math.MinInt64, math.MaxInt64},
+					{new(int), math.MinInt, math.MaxInt},
+					{new(uint8), 0, math.MaxUint8},
+					{new(uint16), 0, math.MaxUint16},
+					{new(uint32), 0, math.MaxUint32},
+					{new(uint64), 0, math.MaxUint64},
+					{new(uint), 0, math.MaxUint},
+				} {
+					err := m.Scan(src.oid, format, buf, dst.target)
+					fits := v >= dst.min && (v < 0 || uint64(v) <= dst.max)
+					if fits {
+						require.NoErrorf(t, err, "oid %d format %d value %d into %T", src.oid, format, v, dst.target)
+						require.Equalf(t, strconv.FormatInt(v, 10), fmt.Sprint(reflect.ValueOf(dst.target).Elem().Interface()), "oid %d format %d value %d into %T", src.oid, format, v, dst.target)
+					} else {
+						require.Errorf(t, err, "oid %d format %d value %d into %T", src.oid, format, v, dst.target)
+					}
+				}
+			}
+		}
+	}
+}
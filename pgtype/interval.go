@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/jackc/pgx/v5/internal/pgio"
 )
@@ -42,6 +44,50 @@ func (interval Interval) IntervalValue() (Interval, error) {
 	return interval, nil
 }
 
+// AddTo returns t plus interval with the same semantics as PostgreSQL timestamptz + interval. Months are added first
+// using calendar arithmetic. If the day of month does not exist in the resulting month it is clamped to the last day
+// of that month (e.g. January 31 plus 1 month is February 28 or 29). Days are then added in t's location preserving
+// the wall clock time across daylight saving time transitions. Finally, Microseconds are added as elapsed time. If
+// interval is not valid t is returned unchanged.
+func (interval Interval) AddTo(t time.Time) time.Time {
+	if !interval.Valid {
+		return t
+	}
+
+	if interval.Months != 0 {
+		year, month, day := t.Date()
+		hour, min, sec := t.Clock()
+
+		months := int(month) - 1 + int(interval.Months)
+		year += months / 12
+		months %= 12
+		if months < 0 {
+			months += 12
+			year--
+		}
+		month = time.Month(months + 1)
+
+		// Day 0 of the following month is the last day of month.
+		if lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day(); day > lastDay {
+			day = lastDay
+		}
+
+		t = time.Date(year, month, day, hour, min, sec, t.Nanosecond(), t.Location())
+	}
+
+	if interval.Days != 0 {
+		t = t.AddDate(0, 0, int(interval.Days))
+	}
+
+	if interval.Microseconds != 0 {
+		seconds := interval.Microseconds / microsecondsPerSecond
+		microseconds := interval.Microseconds % microsecondsPerSecond
+		t = t.Add(time.Duration(seconds) * time.Second).Add(time.Duration(microseconds) * time.Microsecond)
+	}
+
+	return t
+}
+
 // Scan implements the database/sql Scanner interface.
 func (interval *Interval) Scan(src any) error {
 	if src == nil {
@@ -70,6 +116,9 @@ func (interval Interval) Value() (driver.Value, error) {
 	return string(buf), err
 }
 
+// IntervalCodec is the codec for the interval type. Intervals in the text format are parsed in any of the IntervalStyle
+// output formats: postgres, postgres_verbose, sql_standard, and iso_8601. Unlike DateStyle, the IntervalStyle does not
+// need to be changed when connecting.
 type IntervalCodec struct{}
 
 func (IntervalCodec) FormatSupported(format int16) bool {
@@ -87,7 +136,7 @@ func (IntervalCodec) PlanEncode(m *Map, oid uint32, format int16, value any) Enc
 
 	switch format {
 	case BinaryFormatCode:
-		return encodePlanIntervalCodecBinary{}
+		return encodePlanIntervalCodecBinary{floatDatetimes: m.usesFloatDatetimes()}
 	case TextFormatCode:
 		return encodePlanIntervalCodecText{}
 	}
@@ -95,9 +144,9 @@ func (IntervalCodec) PlanEncode(m *Map, oid uint32, format int16, value any) Enc
 	return nil
 }
 
-type encodePlanIntervalCodecBinary struct{}
+type encodePlanIntervalCodecBinary struct{ floatDatetimes bool }
 
-func (encodePlanIntervalCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
+func (plan encodePlanIntervalCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
 	interval, err := value.(IntervalValuer).IntervalValue()
 	if err != nil {
 		return nil, err
@@ -107,7 +156,7 @@ func (encodePlanIntervalCodecBinary) Encode(value any, buf []byte) (newBuf []byt
 		return nil, nil
 	}
 
-	buf = pgio.AppendInt64(buf, interval.Microseconds)
+	buf = appendDatetimeMicroseconds(buf, interval.Microseconds, plan.floatDatetimes)
 	buf = pgio.AppendInt32(buf, interval.Days)
 	buf = pgio.AppendInt32(buf, interval.Months)
 	return buf, nil
@@ -157,7 +206,7 @@ func (IntervalCodec) PlanScan(m *Map, oid uint32, format int16, target any) Scan
 	case BinaryFormatCode:
 		switch target.(type) {
 		case IntervalScanner:
-			return scanPlanBinaryIntervalToIntervalScanner{}
+			return scanPlanBinaryIntervalToIntervalScanner{floatDatetimes: m.usesFloatDatetimes()}
 		}
 	case TextFormatCode:
 		switch target.(type) {
@@ -169,9 +218,9 @@ func (IntervalCodec) PlanScan(m *Map, oid uint32, format int16, target any) Scan
 	return nil
 }
 
-type scanPlanBinaryIntervalToIntervalScanner struct{}
+type scanPlanBinaryIntervalToIntervalScanner struct{ floatDatetimes bool }
 
-func (scanPlanBinaryIntervalToIntervalScanner) Scan(src []byte, dst any) error {
+func (plan scanPlanBinaryIntervalToIntervalScanner) Scan(src []byte, dst any) error {
 	scanner := (dst).(IntervalScanner)
 
 	if src == nil {
@@ -182,7 +231,7 @@ func (scanPlanBinaryIntervalToIntervalScanner) Scan(src []byte, dst any) error {
 		return fmt.Errorf("Received an invalid size for an interval: %d", len(src))
 	}
 
-	microseconds := int64(binary.BigEndian.Uint64(src))
+	microseconds := readDatetimeMicroseconds(src, plan.floatDatetimes)
 	days := int32(binary.BigEndian.Uint32(src[8:]))
 	months := int32(binary.BigEndian.Uint32(src[12:]))
 
@@ -198,16 +247,40 @@ func (scanPlanTextAnyToIntervalScanner) Scan(src []byte, dst any) error {
 		return scanner.ScanInterval(Interval{})
 	}
 
+	interval, err := parseInterval(string(src))
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanInterval(interval)
+}
+
+// parseInterval parses s in any of the IntervalStyle output formats.
+func parseInterval(s string) (Interval, error) {
+	switch {
+	case strings.HasPrefix(s, "@"):
+		return parseIntervalPostgresVerbose(s)
+	case strings.HasPrefix(s, "P"):
+		return parseIntervalISO8601(s)
+	case strings.IndexFunc(s, unicode.IsLetter) == -1:
+		return parseIntervalSQLStandard(s)
+	default:
+		return parseIntervalPostgres(s)
+	}
+}
+
+// parseIntervalPostgres parses the postgres IntervalStyle. e.g. "1 year 2 mons 3 days 04:05:06".
+func parseIntervalPostgres(s string) (Interval, error) {
 	var microseconds int64
 	var days int32
 	var months int32
 
-	parts := strings.Split(string(src), " ")
+	parts := strings.Split(s, " ")
 
 	for i := 0; i < len(parts)-1; i += 2 {
 		scalar, err := strconv.ParseInt(parts[i], 10, 64)
 		if err != nil {
-			return fmt.Errorf("bad interval format")
+			return Interval{}, fmt.Errorf("bad interval format")
 		}
 
 		switch parts[i+1] {
@@ -221,57 +294,273 @@ func (scanPlanTextAnyToIntervalScanner) Scan(src []byte, dst any) error {
 	}
 
 	if len(parts)%2 == 1 {
-		timeParts := strings.SplitN(parts[len(parts)-1], ":", 3)
-		if len(timeParts) != 3 {
-			return fmt.Errorf("bad interval format")
+		var err error
+		microseconds, err = parseIntervalTime(parts[len(parts)-1])
+		if err != nil {
+			return Interval{}, err
 		}
+	}
+
+	return Interval{Months: months, Days: days, Microseconds: microseconds, Valid: true}, nil
+}
+
+// parseIntervalPostgresVerbose parses the postgres_verbose IntervalStyle. e.g. "@ 1 year 2 mons 3 days 4 hours 5 mins
+// 6.5 secs ago". A trailing "ago" negates every field.
+func parseIntervalPostgresVerbose(s string) (Interval, error) {
+	fields := strings.Fields(s[1:])
+
+	ago := len(fields) > 0 && fields[len(fields)-1] == "ago"
+	if ago {
+		fields = fields[:len(fields)-1]
+	}
+
+	if len(fields) == 1 && fields[0] == "0" {
+		return Interval{Valid: true}, nil
+	}
+
+	if len(fields)%2 != 0 {
+		return Interval{}, fmt.Errorf("bad interval format: %s", s)
+	}
 
-		var negative bool
-		if timeParts[0][0] == '-' {
-			negative = true
-			timeParts[0] = timeParts[0][1:]
+	var interval Interval
+	for i := 0; i < len(fields); i += 2 {
+		unit := strings.TrimSuffix(fields[i+1], "s")
+		if unit == "sec" {
+			microseconds, err := parseIntervalSeconds(fields[i])
+			if err != nil {
+				return Interval{}, err
+			}
+			interval.Microseconds += microseconds
+			continue
 		}
 
-		hours, err := strconv.ParseInt(timeParts[0], 10, 64)
+		scalar, err := strconv.ParseInt(fields[i], 10, 64)
 		if err != nil {
-			return fmt.Errorf("bad interval hour format: %s", timeParts[0])
+			return Interval{}, fmt.Errorf("bad interval format: %s", s)
 		}
 
-		minutes, err := strconv.ParseInt(timeParts[1], 10, 64)
-		if err != nil {
-			return fmt.Errorf("bad interval minute format: %s", timeParts[1])
+		switch unit {
+		case "year":
+			interval.Months += int32(scalar * 12)
+		case "mon":
+			interval.Months += int32(scalar)
+		case "day":
+			interval.Days += int32(scalar)
+		case "hour":
+			interval.Microseconds += scalar * microsecondsPerHour
+		case "min":
+			interval.Microseconds += scalar * microsecondsPerMinute
+		default:
+			return Interval{}, fmt.Errorf("bad interval unit: %s", fields[i+1])
 		}
+	}
+
+	if ago {
+		interval.Months = -interval.Months
+		interval.Days = -interval.Days
+		interval.Microseconds = -interval.Microseconds
+	}
 
-		sec, secFrac, secFracFound := strings.Cut(timeParts[2], ".")
+	interval.Valid = true
+	return interval, nil
+}
 
-		seconds, err := strconv.ParseInt(sec, 10, 64)
-		if err != nil {
-			return fmt.Errorf("bad interval second format: %s", sec)
+// parseIntervalSQLStandard parses the sql_standard IntervalStyle. e.g. "1-2 3 4:05:06". A value with both year-month
+// and day-time fields or with fields of mixed signs has an explicit sign on each of its three fields. Otherwise, a
+// leading minus sign negates the entire value.
+func parseIntervalSQLStandard(s string) (Interval, error) {
+	parts := strings.Split(s, " ")
+
+	negative := len(parts) < 3 && strings.HasPrefix(parts[0], "-")
+	if negative {
+		parts[0] = parts[0][1:]
+	}
+
+	var interval Interval
+	for _, part := range parts {
+		if part == "" {
+			return Interval{}, fmt.Errorf("bad interval format: %s", s)
 		}
 
-		var uSeconds int64
-		if secFracFound {
-			uSeconds, err = strconv.ParseInt(secFrac, 10, 64)
+		switch {
+		case strings.Contains(part, ":"):
+			microseconds, err := parseIntervalTime(part)
 			if err != nil {
-				return fmt.Errorf("bad interval decimal format: %s", secFrac)
+				return Interval{}, err
+			}
+			interval.Microseconds = microseconds
+		case strings.Contains(part[1:], "-"):
+			var sign int64 = 1
+			switch part[0] {
+			case '-':
+				sign = -1
+				part = part[1:]
+			case '+':
+				part = part[1:]
 			}
 
-			for i := 0; i < 6-len(secFrac); i++ {
-				uSeconds *= 10
+			yearStr, monthStr, _ := strings.Cut(part, "-")
+			years, err := strconv.ParseInt(yearStr, 10, 32)
+			if err != nil {
+				return Interval{}, fmt.Errorf("bad interval year format: %s", yearStr)
+			}
+			months, err := strconv.ParseInt(monthStr, 10, 32)
+			if err != nil {
+				return Interval{}, fmt.Errorf("bad interval month format: %s", monthStr)
+			}
+			interval.Months = int32(sign * (years*12 + months))
+		default:
+			days, err := strconv.ParseInt(part, 10, 32)
+			if err != nil {
+				return Interval{}, fmt.Errorf("bad interval day format: %s", part)
 			}
+			interval.Days = int32(days)
 		}
+	}
+
+	if negative {
+		interval.Months = -interval.Months
+		interval.Days = -interval.Days
+		interval.Microseconds = -interval.Microseconds
+	}
+
+	interval.Valid = true
+	return interval, nil
+}
+
+// parseIntervalISO8601 parses the iso_8601 IntervalStyle. e.g. "P1Y2M3DT4H5M6.5S". Each field may have its own sign.
+func parseIntervalISO8601(s string) (Interval, error) {
+	var interval Interval
+	var inTime bool
+
+	rest := s[1:]
+	if rest == "" {
+		return Interval{}, fmt.Errorf("bad interval format: %s", s)
+	}
+
+	for len(rest) > 0 {
+		if rest[0] == 'T' {
+			inTime = true
+			rest = rest[1:]
+			continue
+		}
+
+		i := strings.IndexFunc(rest, unicode.IsLetter)
+		if i <= 0 {
+			return Interval{}, fmt.Errorf("bad interval format: %s", s)
+		}
+		value, designator := rest[:i], rest[i]
+		rest = rest[i+1:]
 
-		microseconds = hours * microsecondsPerHour
-		microseconds += minutes * microsecondsPerMinute
-		microseconds += seconds * microsecondsPerSecond
-		microseconds += uSeconds
+		if inTime && designator == 'S' {
+			microseconds, err := parseIntervalSeconds(value)
+			if err != nil {
+				return Interval{}, err
+			}
+			interval.Microseconds += microseconds
+			continue
+		}
 
-		if negative {
-			microseconds = -microseconds
+		scalar, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return Interval{}, fmt.Errorf("bad interval format: %s", s)
 		}
+
+		switch {
+		case !inTime && designator == 'Y':
+			interval.Months += int32(scalar * 12)
+		case !inTime && designator == 'M':
+			interval.Months += int32(scalar)
+		case !inTime && designator == 'W':
+			interval.Days += int32(scalar * 7)
+		case !inTime && designator == 'D':
+			interval.Days += int32(scalar)
+		case inTime && designator == 'H':
+			interval.Microseconds += scalar * microsecondsPerHour
+		case inTime && designator == 'M':
+			interval.Microseconds += scalar * microsecondsPerMinute
+		default:
+			return Interval{}, fmt.Errorf("bad interval format: %s", s)
+		}
+	}
+
+	interval.Valid = true
+	return interval, nil
+}
+
+// parseIntervalTime parses a time of the form [+-]H:MM:SS[.ffffff] into microseconds.
+func parseIntervalTime(s string) (int64, error) {
+	var negative bool
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	timeParts := strings.SplitN(s, ":", 3)
+	if len(timeParts) != 3 {
+		return 0, fmt.Errorf("bad interval format")
+	}
+
+	hours, err := strconv.ParseInt(timeParts[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad interval hour format: %s", timeParts[0])
+	}
+
+	minutes, err := strconv.ParseInt(timeParts[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad interval minute format: %s", timeParts[1])
+	}
+
+	seconds, err := parseIntervalSeconds(timeParts[2])
+	if err != nil {
+		return 0, err
+	}
+
+	microseconds := hours*microsecondsPerHour + minutes*microsecondsPerMinute + seconds
+	if negative {
+		microseconds = -microseconds
+	}
+
+	return microseconds, nil
+}
+
+// parseIntervalSeconds parses seconds with an optional sign and fractional part into microseconds.
+func parseIntervalSeconds(s string) (int64, error) {
+	var negative bool
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	sec, secFrac, secFracFound := strings.Cut(s, ".")
+
+	seconds, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad interval second format: %s", sec)
+	}
+
+	var uSeconds int64
+	if secFracFound {
+		if len(secFrac) > 6 {
+			return 0, fmt.Errorf("bad interval decimal format: %s", secFrac)
+		}
+
+		uSeconds, err = strconv.ParseInt(secFrac, 10, 64)
+		if err != nil || uSeconds < 0 {
+			return 0, fmt.Errorf("bad interval decimal format: %s", secFrac)
+		}
+
+		for i := 0; i < 6-len(secFrac); i++ {
+			uSeconds *= 10
+		}
+	}
+
+	microseconds := seconds*microsecondsPerSecond + uSeconds
+	if negative {
+		microseconds = -microseconds
 	}
 
-	return scanner.ScanInterval(Interval{Months: months, Days: days, Microseconds: microseconds, Valid: true})
+	return microseconds, nil
 }
 
 func (c IntervalCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
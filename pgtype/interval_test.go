@@ -2,11 +2,16 @@ package pgtype_test
 
 import (
 	"context"
+	"encoding/binary"
+	"math"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIntervalCodec(t *testing.T) {
@@ -136,3 +141,200 @@ func TestIntervalCodec(t *testing.T) {
 		{nil, new(pgtype.Interval), isExpectedEq(pgtype.Interval{})},
 	})
 }
+
+func TestIntervalCodecScanTextIntervalStyles(t *testing.T) {
+	m := pgtype.NewMap()
+
+	positive := pgtype.Interval{Months: 14, Days: 3, Microseconds: 14706500000, Valid: true}
+	negative := pgtype.Interval{Months: -14, Days: -3, Microseconds: -14706500000, Valid: true}
+	mixed := pgtype.Interval{Months: 12, Days: -2, Microseconds: 3 * 3600000000, Valid: true}
+	zero := pgtype.Interval{Valid: true}
+
+	for i, tt := range []struct {
+		src      string
+		expected pgtype.Interval
+	}{
+		// postgres
+		{"1 year 2 mons 3 days 04:05:06.5", positive},
+		{"-1 years -2 mons -3 days -04:05:06.5", negative},
+		{"1 year -2 days +03:00:00", mixed},
+		{"00:00:00", zero},
+
+		// postgres_verbose
+		{"@ 1 year 2 mons 3 days 4 hours 5 mins 6.5 secs", positive},
+		{"@ 1 year 2 mons 3 days 4 hours 5 mins 6.5 secs ago", negative},
+		{"@ 1 year -2 days 3 hours", mixed},
+		{"@ 0", zero},
+
+		// sql_standard
+		{"+1-2 +3 +4:05:06.5", positive},
+		{"-1-2 -3 -4:05:06.5", negative},
+		{"+1-0 -2 +3:00:00", mixed},
+		{"-1-2", pgtype.Interval{Months: -14, Valid: true}},
+		{"-3 4:05:06.5", pgtype.Interval{Days: -3, Microseconds: -14706500000, Valid: true}},
+		{"0", zero},
+
+		// iso_8601
+		{"P1Y2M3DT4H5M6.5S", positive},
+		{"P-1Y-2M-3DT-4H-5M-6.5S", negative},
+		{"P1Y-2DT3H", mixed},
+		{"PT-0.000001S", pgtype.Interval{Microseconds: -1, Valid: true}},
+		{"PT0S", zero},
+	} {
+		var interval pgtype.Interval
+		err := m.Scan(pgtype.IntervalOID, pgtype.TextFormatCode, []byte(tt.src), &interval)
+		if assert.NoErrorf(t, err, "%d: %s", i, tt.src) {
+			assert.Equalf(t, tt.expected, interval, "%d: %s", i, tt.src)
+		}
+	}
+
+	for i, src := range []string{"P", "P1X", "PT1.5H", "@ 1", "@ 1 fortnight", "1-x", "04:05"} {
+		var interval pgtype.Interval
+		err := m.Scan(pgtype.IntervalOID, pgtype.TextFormatCode, []byte(src), &interval)
+		assert.Errorf(t, err, "%d: %s", i, src)
+	}
+}
+
+func TestIntervalCodecIntervalStyles(t *testing.T) {
+	skipCockroachDB(t, "Server does not support IntervalStyle")
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		for _, style := range []string{"postgres", "postgres_verbose", "sql_standard", "iso_8601"} {
+			_, err := conn.Exec(ctx, "set IntervalStyle = "+style)
+			require.NoError(t, err)
+
+			for _, expected := range []pgtype.Interval{
+				{Months: 14, Days: 3, Microseconds: 14706500000, Valid: true},
+				{Months: -14, Days: -3, Microseconds: -14706500000, Valid: true},
+				{Months: 12, Days: -2, Microseconds: 3 * 3600000000, Valid: true},
+				{Valid: true},
+			} {
+				var actual pgtype.Interval
+				err := conn.QueryRow(ctx, "select $1::interval", pgx.QueryExecModeSimpleProtocol, expected).Scan(&actual)
+				require.NoErrorf(t, err, "%s", style)
+				assert.Equalf(t, expected, actual, "%s", style)
+			}
+		}
+	})
+}
+
+func TestIntervalAddTo(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	for i, tt := range []struct {
+		interval pgtype.Interval
+		t        time.Time
+		expected time.Time
+	}{
+		{
+			interval: pgtype.Interval{Months: 1, Valid: true},
+			t:        time.Date(2023, 1, 31, 10, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 2, 28, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			interval: pgtype.Interval{Months: 1, Valid: true},
+			t:        time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			interval: pgtype.Interval{Months: -1, Valid: true},
+			t:        time.Date(2023, 3, 31, 10, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 2, 28, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			interval: pgtype.Interval{Months: 14, Valid: true},
+			t:        time.Date(2023, 12, 15, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			interval: pgtype.Interval{Months: -13, Valid: true},
+			t:        time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2021, 12, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			interval: pgtype.Interval{Months: 1, Days: 1, Microseconds: 3723000001, Valid: true},
+			t:        time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 3, 1, 1, 2, 3, 1000, time.UTC),
+		},
+		// Adding a day across the start of daylight saving time preserves the wall clock time.
+		{
+			interval: pgtype.Interval{Days: 1, Valid: true},
+			t:        time.Date(2023, 3, 11, 12, 0, 0, 0, newYork),
+			expected: time.Date(2023, 3, 12, 12, 0, 0, 0, newYork),
+		},
+		// Adding 24 hours across the start of daylight saving time adds elapsed time.
+		{
+			interval: pgtype.Interval{Microseconds: 24 * 60 * 60 * 1000000, Valid: true},
+			t:        time.Date(2023, 3, 11, 12, 0, 0, 0, newYork),
+			expected: time.Date(2023, 3, 12, 13, 0, 0, 0, newYork),
+		},
+		{
+			interval: pgtype.Interval{Months: 1, Days: 1, Microseconds: 1},
+			t:        time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+	} {
+		actual := tt.interval.AddTo(tt.t)
+		assert.Truef(t, tt.expected.Equal(actual), "%d: expected %v, got %v", i, tt.expected, actual)
+	}
+}
+
+func TestIntervalAddToMatchesPostgreSQL(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		_, err := conn.Exec(ctx, "set time zone 'America/New_York'")
+		require.NoError(t, err)
+
+		newYork, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+
+		for i, tt := range []struct {
+			t        time.Time
+			interval string
+		}{
+			{time.Date(2023, 1, 31, 10, 0, 0, 0, newYork), "1 month"},
+			{time.Date(2023, 3, 11, 12, 0, 0, 0, newYork), "1 day"},
+			{time.Date(2023, 3, 11, 12, 0, 0, 0, newYork), "24 hours"},
+			{time.Date(2023, 10, 31, 1, 30, 0, 0, newYork), "1 month 1 day 1 hour"},
+			{time.Date(2024, 3, 31, 23, 0, 0, 0, newYork), "-1 month -2 days -3.5 seconds"},
+		} {
+			var interval pgtype.Interval
+			var expected time.Time
+			err := conn.QueryRow(ctx, "select $2::interval, $1::timestamptz + $2::interval", tt.t, tt.interval).Scan(&interval, &expected)
+			require.NoErrorf(t, err, "%d", i)
+			assert.Truef(t, expected.Equal(interval.AddTo(tt.t)), "%d: expected %v, got %v", i, expected, interval.AddTo(tt.t))
+		}
+	})
+}
+
+func TestIntervalCodecBinaryFloatDatetimes(t *testing.T) {
+	for _, tt := range []struct {
+		value        pgtype.Interval
+		integerValue int64
+		floatValue   float64
+	}{
+		{pgtype.Interval{Microseconds: 1500000, Days: 2, Months: 3, Valid: true}, 1500000, 1.5},
+		{pgtype.Interval{Microseconds: -3600000000, Days: -1, Months: 0, Valid: true}, -3600000000, -3600},
+	} {
+		for _, floatDatetimes := range []bool{false, true} {
+			m := pgtype.NewMap()
+			m.SetFloatDatetimes(floatDatetimes)
+
+			src := binary.BigEndian.AppendUint64(nil, uint64(tt.integerValue))
+			if floatDatetimes {
+				src = binary.BigEndian.AppendUint64(nil, math.Float64bits(tt.floatValue))
+			}
+			src = binary.BigEndian.AppendUint32(src, uint32(tt.value.Days))
+			src = binary.BigEndian.AppendUint32(src, uint32(tt.value.Months))
+
+			var interval pgtype.Interval
+			err := m.Scan(pgtype.IntervalOID, pgtype.BinaryFormatCode, src, &interval)
+			require.NoErrorf(t, err, "%v %v", tt.value, floatDatetimes)
+			require.Equalf(t, tt.value, interval, "%v %v", tt.value, floatDatetimes)
+
+			buf, err := m.Encode(pgtype.IntervalOID, pgtype.BinaryFormatCode, tt.value, nil)
+			require.NoErrorf(t, err, "%v %v", tt.value, floatDatetimes)
+			require.Equalf(t, src, buf, "%v %v", tt.value, floatDatetimes)
+		}
+	}
+}
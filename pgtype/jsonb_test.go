@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
 	"github.com/stretchr/testify/require"
 )
@@ -34,6 +35,44 @@ func TestJSONBTranscode(t *testing.T) {
 	})
 }
 
+type jsonbNestedAddress struct {
+	City string   `json:"city"`
+	Tags []string `json:"tags"`
+}
+
+type jsonbNestedPerson struct {
+	Name    string             `json:"name"`
+	Address jsonbNestedAddress `json:"address"`
+}
+
+func TestJSONBCodecScanNestedStruct(t *testing.T) {
+	pgxtest.RunWithQueryExecModes(context.Background(), t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var person jsonbNestedPerson
+		err := conn.QueryRow(ctx, `select '{"name": "Adam", "address": {"city": "Dallas", "tags": ["a", "b"]}}'::jsonb`).Scan(&person)
+		require.NoError(t, err)
+		require.Equal(t, jsonbNestedPerson{Name: "Adam", Address: jsonbNestedAddress{City: "Dallas", Tags: []string{"a", "b"}}}, person)
+
+		var m map[string]interface{}
+		err = conn.QueryRow(ctx, `select '{"name": "Adam", "address": {"city": "Dallas"}}'::jsonb`).Scan(&m)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"name": "Adam", "address": map[string]interface{}{"city": "Dallas"}}, m)
+	})
+}
+
+func TestJSONBCodecScanBinaryIntoStruct(t *testing.T) {
+	m := pgtype.NewMap()
+	src := append([]byte{1}, `{"name": "Adam", "address": {"city": "Dallas", "tags": ["a"]}}`...)
+
+	var person jsonbNestedPerson
+	err := m.Scan(pgtype.JSONBOID, pgtype.BinaryFormatCode, src, &person)
+	require.NoError(t, err)
+	require.Equal(t, jsonbNestedPerson{Name: "Adam", Address: jsonbNestedAddress{City: "Dallas", Tags: []string{"a"}}}, person)
+
+	src[0] = 2
+	err = m.Scan(pgtype.JSONBOID, pgtype.BinaryFormatCode, src, &person)
+	require.ErrorContains(t, err, "unknown jsonb version number 2")
+}
+
 func TestJSONBCodecUnmarshalSQLNull(t *testing.T) {
 	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
 		// Slices are nilified
@@ -0,0 +1,197 @@
+package pgtype
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgx/v5/internal/pgio"
+)
+
+// MoneyCodec is the codec for the PostgreSQL money type.
+//
+// MoneyCodec prefers the binary format. It is a count of the smallest currency unit as an int64 (e.g. 12345 cents for
+// $123.45) and, unlike the text format, does not depend on the server's lc_monetary setting. Money can be scanned into
+// int64, the other integer types supported by Int8Codec, and Numeric. Integer and Numeric parameters are encoded in the
+// binary format. Scanning the binary format into a string or TextScanner produces a plain decimal such as 123.45.
+// Rows.Values and database/sql return the int64 count.
+//
+// The text format (e.g. $123.45 or 123,45 €) is used with QueryExecModeSimpleProtocol or when requested explicitly. It
+// can only be scanned into string, []byte, or a TextScanner. MoneyCodec does not parse it.
+//
+// Numeric values are converted assuming two fractional digits, which is correct for most locales. e.g. a money value of
+// $123.45 is scanned into a Numeric as 123.45 and a Numeric of 123.45 is encoded as 12345 cents. Encoding a Numeric
+// with more than two fractional digits is an error rather than rounding.
+//
+// With QueryExecModeExec and QueryExecModeSimpleProtocol the parameter type is not known so an integer is sent as text
+// and is interpreted by the server as whole currency units rather than cents.
+type MoneyCodec struct{}
+
+// moneyFractionalDigits is the number of fractional digits assumed when converting money to and from Numeric.
+const moneyFractionalDigits = 2
+
+func (MoneyCodec) FormatSupported(format int16) bool {
+	return format == TextFormatCode || format == BinaryFormatCode
+}
+
+func (MoneyCodec) PreferredFormat() int16 {
+	return BinaryFormatCode
+}
+
+func (MoneyCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	if format == TextFormatCode {
+		switch value.(type) {
+		case string:
+			return encodePlanTextCodecString{}
+		case []byte:
+			return encodePlanTextCodecByteSlice{}
+		case TextValuer:
+			return encodePlanTextCodecTextValuer{}
+		}
+		return nil
+	}
+
+	// Numeric is also an Int64Valuer so it must be checked first.
+	if _, ok := value.(NumericValuer); ok {
+		return encodePlanMoneyCodecBinaryNumericValuer{}
+	}
+
+	return Int8Codec{}.PlanEncode(m, oid, format, value)
+}
+
+type encodePlanMoneyCodecBinaryNumericValuer struct{}
+
+func (encodePlanMoneyCodecBinaryNumericValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	n, err := value.(NumericValuer).NumericValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !n.Valid {
+		return nil, nil
+	}
+
+	if n.NaN || n.InfinityModifier != Finite {
+		return nil, fmt.Errorf("cannot encode %v as money", n)
+	}
+
+	if n.Int == nil {
+		n.Int = big.NewInt(0)
+	}
+
+	cents, err := Numeric{Int: n.Int, Exp: n.Exp + moneyFractionalDigits, Valid: true}.Int64Value()
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode %v as money: %w", n, err)
+	}
+
+	return pgio.AppendInt64(buf, cents.Int64), nil
+}
+
+func (MoneyCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	if format == TextFormatCode {
+		switch target.(type) {
+		case *string:
+			return scanPlanTextAnyToString{}
+		case *[]byte:
+			return scanPlanAnyToNewByteSlice{}
+		case TextScanner:
+			return scanPlanTextAnyToTextScanner{}
+		}
+		return nil
+	}
+
+	switch target.(type) {
+	// Numeric is also an Int64Scanner so it must be checked first.
+	case NumericScanner:
+		return scanPlanBinaryMoneyToNumericScanner{}
+	case *string:
+		return scanPlanBinaryMoneyToString{}
+	case TextScanner:
+		return scanPlanBinaryMoneyToTextScanner{}
+	}
+
+	return Int8Codec{}.PlanScan(m, oid, format, target)
+}
+
+// formatMoneyCents formats cents as a decimal with moneyFractionalDigits fractional digits.
+func formatMoneyCents(cents int64) string {
+	var sign string
+	abs := uint64(cents)
+	if cents < 0 {
+		sign = "-"
+		abs = -abs
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, abs/100, abs%100)
+}
+
+type scanPlanBinaryMoneyToString struct{}
+
+func (scanPlanBinaryMoneyToString) Scan(src []byte, dst any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", dst)
+	}
+
+	if len(src) != 8 {
+		return fmt.Errorf("invalid length for money: %v", len(src))
+	}
+
+	*(dst.(*string)) = formatMoneyCents(int64(binary.BigEndian.Uint64(src)))
+	return nil
+}
+
+type scanPlanBinaryMoneyToTextScanner struct{}
+
+func (scanPlanBinaryMoneyToTextScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(TextScanner)
+
+	if src == nil {
+		return scanner.ScanText(Text{})
+	}
+
+	if len(src) != 8 {
+		return fmt.Errorf("invalid length for money: %v", len(src))
+	}
+
+	return scanner.ScanText(Text{String: formatMoneyCents(int64(binary.BigEndian.Uint64(src))), Valid: true})
+}
+
+type scanPlanBinaryMoneyToNumericScanner struct{}
+
+func (scanPlanBinaryMoneyToNumericScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(NumericScanner)
+
+	if src == nil {
+		return scanner.ScanNumeric(Numeric{})
+	}
+
+	if len(src) != 8 {
+		return fmt.Errorf("invalid length for money: %v", len(src))
+	}
+
+	cents := int64(binary.BigEndian.Uint64(src))
+
+	return scanner.ScanNumeric(Numeric{Int: big.NewInt(cents), Exp: -moneyFractionalDigits, Valid: true})
+}
+
+func (c MoneyCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	return c.DecodeValue(m, oid, format, src)
+}
+
+func (c MoneyCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	if format == TextFormatCode {
+		return string(src), nil
+	}
+
+	var n int64
+	err := codecScan(c, m, oid, format, src, &n)
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
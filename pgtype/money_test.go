@@ -0,0 +1,162 @@
+package pgtype_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoneyCodec(t *testing.T) {
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, pgxtest.KnownOIDQueryExecModes, "money", []pgxtest.ValueRoundTripTest{
+		{int64(12345), new(int64), isExpectedEq(int64(12345))},
+		{int64(-12345), new(int64), isExpectedEq(int64(-12345))},
+		{int64(0), new(int64), isExpectedEq(int64(0))},
+		{pgtype.Int8{Int64: 12345, Valid: true}, new(pgtype.Int8), isExpectedEq(pgtype.Int8{Int64: 12345, Valid: true})},
+		{pgtype.Numeric{Int: big.NewInt(12345), Exp: -2, Valid: true}, new(int64), isExpectedEq(int64(12345))},
+		{pgtype.Numeric{Int: big.NewInt(123), Exp: 0, Valid: true}, new(int64), isExpectedEq(int64(12300))},
+		{int64(12345), new(pgtype.Numeric), isExpectedEqNumeric(pgtype.Numeric{Int: big.NewInt(12345), Exp: -2, Valid: true})},
+		{pgtype.Int8{}, new(pgtype.Int8), isExpectedEq(pgtype.Int8{})},
+		{nil, new(*int64), isExpectedEq((*int64)(nil))},
+	})
+}
+
+func TestMoneyCodecIndependentOfLocale(t *testing.T) {
+	pgxtest.RunWithQueryExecModes(context.Background(), t, defaultConnTestRunner, pgxtest.KnownOIDQueryExecModes, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does not support money")
+
+		// Use a locale with a comma as the decimal separator. Which locales are available depends on the server.
+		localeSet := false
+		for _, locale := range []string{"de_DE.UTF-8", "de_DE.utf8", "de_DE", "fr_FR.UTF-8", "fr_FR.utf8", "nl_NL.UTF-8"} {
+			_, err := conn.Exec(ctx, "set lc_monetary = '"+locale+"'")
+			if err == nil {
+				localeSet = true
+				break
+			}
+		}
+		if !localeSet {
+			t.Skip("Server does not have a locale with a comma decimal separator")
+		}
+
+		var text string
+		err := conn.QueryRow(ctx, "select 123.45::numeric::money::text").Scan(&text)
+		require.NoError(t, err)
+		require.Contains(t, text, "123,45")
+
+		var cents int64
+		var amount pgtype.Numeric
+		err = conn.QueryRow(ctx, "select 123.45::numeric::money, $1::money", int64(12345)).Scan(&cents, &amount)
+		require.NoError(t, err)
+		require.EqualValues(t, 12345, cents)
+		require.True(t, isExpectedEqNumeric(pgtype.Numeric{Int: big.NewInt(12345), Exp: -2, Valid: true})(amount))
+
+		var equal bool
+		err = conn.QueryRow(ctx, "select $1::money = 123.45::numeric::money", pgtype.Numeric{Int: big.NewInt(12345), Exp: -2, Valid: true}).Scan(&equal)
+		require.NoError(t, err)
+		require.True(t, equal)
+	})
+}
+
+func TestMoneyCodecEncodeNumeric(t *testing.T) {
+	m := pgtype.NewMap()
+
+	buf, err := m.Encode(pgtype.MoneyOID, pgtype.BinaryFormatCode, pgtype.Numeric{Int: big.NewInt(1234500), Exp: -4, Valid: true}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0x30, 0x39}, buf)
+
+	for _, n := range []pgtype.Numeric{
+		{Int: big.NewInt(123451), Exp: -3, Valid: true},
+		{NaN: true, Valid: true},
+		{InfinityModifier: pgtype.Infinity, Valid: true},
+	} {
+		_, err = m.Encode(pgtype.MoneyOID, pgtype.BinaryFormatCode, n, nil)
+		require.Error(t, err)
+	}
+
+	var n pgtype.Numeric
+	err = m.Scan(pgtype.MoneyOID, pgtype.BinaryFormatCode, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xcf, 0xc7}, &n)
+	require.NoError(t, err)
+	require.Equal(t, "-12345", n.Int.String())
+	require.EqualValues(t, -2, n.Exp)
+}
+
+func TestMoneyCodecFormats(t *testing.T) {
+	m := pgtype.NewMap()
+	require.Equal(t, int16(pgtype.BinaryFormatCode), m.FormatCodeForOID(pgtype.MoneyOID))
+
+	buf, err := m.Encode(pgtype.MoneyOID, m.FormatCodeForOID(pgtype.MoneyOID), pgtype.Numeric{Int: big.NewInt(12345), Exp: -2, Valid: true}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0x30, 0x39}, buf)
+
+	for _, tt := range []struct {
+		src      []byte
+		expected string
+	}{
+		{src: []byte{0, 0, 0, 0, 0, 0, 0x30, 0x39}, expected: "123.45"},
+		{src: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xcf, 0xc7}, expected: "-123.45"},
+		{src: []byte{0, 0, 0, 0, 0, 0, 0, 5}, expected: "0.05"},
+		{src: []byte{0x80, 0, 0, 0, 0, 0, 0, 0}, expected: "-92233720368547758.08"},
+	} {
+		var s string
+		err = m.Scan(pgtype.MoneyOID, pgtype.BinaryFormatCode, tt.src, &s)
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, s)
+
+		var text pgtype.Text
+		err = m.Scan(pgtype.MoneyOID, pgtype.BinaryFormatCode, tt.src, &text)
+		require.NoError(t, err)
+		require.Equal(t, pgtype.Text{String: tt.expected, Valid: true}, text)
+	}
+
+	moneyType, ok := m.TypeForOID(pgtype.MoneyOID)
+	require.True(t, ok)
+
+	v, err := moneyType.Codec.DecodeValue(m, pgtype.MoneyOID, pgtype.BinaryFormatCode, []byte{0, 0, 0, 0, 0, 0, 0x30, 0x39})
+	require.NoError(t, err)
+	require.Equal(t, int64(12345), v)
+
+	// The text format is passed through unparsed.
+	var s string
+	err = m.Scan(pgtype.MoneyOID, pgtype.TextFormatCode, []byte("$123.45"), &s)
+	require.NoError(t, err)
+	require.Equal(t, "$123.45", s)
+
+	v, err = moneyType.Codec.DecodeValue(m, pgtype.MoneyOID, pgtype.TextFormatCode, []byte("$123.45"))
+	require.NoError(t, err)
+	require.Equal(t, "$123.45", v)
+
+	dv, err := moneyType.Codec.DecodeDatabaseSQLValue(m, pgtype.MoneyOID, pgtype.TextFormatCode, []byte("$123.45"))
+	require.NoError(t, err)
+	require.Equal(t, "$123.45", dv)
+
+	buf, err = m.Encode(pgtype.MoneyOID, pgtype.TextFormatCode, "$1.50", nil)
+	require.NoError(t, err)
+	require.Equal(t, "$1.50", string(buf))
+}
+
+func TestMoneyCodecScanString(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does not support money")
+
+		_, err := conn.Exec(ctx, "set lc_monetary = 'C'")
+		require.NoError(t, err)
+
+		var s string
+		err = conn.QueryRow(ctx, "select 123.45::numeric::money").Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "123.45", s)
+
+		rows, _ := conn.Query(ctx, "select 123.45::numeric::money")
+		values, err := pgx.CollectOneRow(rows, func(row pgx.CollectableRow) ([]any, error) { return row.Values() })
+		require.NoError(t, err)
+		require.Equal(t, []any{int64(12345)}, values)
+
+		err = conn.QueryRow(ctx, "select 123.45::numeric::money", pgx.QueryExecModeSimpleProtocol).Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "$123.45", s)
+	})
+}
@@ -145,6 +145,86 @@ func TestNumericCodecInfinity(t *testing.T) {
 	})
 }
 
+func TestNumericCodecBinarySpecialValues(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for i, tt := range []struct {
+		numeric pgtype.Numeric
+		binary  []byte
+	}{
+		{pgtype.Numeric{NaN: true, Valid: true}, []byte{0, 0, 0, 0, 0xc0, 0, 0, 0}},
+		{pgtype.Numeric{InfinityModifier: pgtype.Infinity, Valid: true}, []byte{0, 0, 0, 0, 0xd0, 0, 0, 0}},
+		{pgtype.Numeric{InfinityModifier: pgtype.NegativeInfinity, Valid: true}, []byte{0, 0, 0, 0, 0xf0, 0, 0, 0}},
+	} {
+		buf, err := m.Encode(pgtype.NumericOID, pgtype.BinaryFormatCode, tt.numeric, nil)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, tt.binary, buf, "%d", i)
+
+		var n pgtype.Numeric
+		err = m.Scan(pgtype.NumericOID, pgtype.BinaryFormatCode, tt.binary, &n)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, tt.numeric, n, "%d", i)
+	}
+}
+
+func TestNumericCodecBinaryDigitGroups(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for i, tt := range []struct {
+		s      string
+		binary []byte
+	}{
+		// ndigits, weight, sign, dscale, then base 10000 digits.
+		{"123456.789", []byte{0, 3, 0, 1, 0, 0, 0, 3, 0, 12, 0x0d, 0x80, 0x1e, 0xd2}},
+		{"0.001", []byte{0, 1, 0xff, 0xff, 0, 0, 0, 3, 0, 10}},
+		{"-1234.5", []byte{0, 2, 0, 0, 0x40, 0, 0, 1, 0x04, 0xd2, 0x13, 0x88}},
+		{"-100000", []byte{0, 1, 0, 1, 0x40, 0, 0, 0, 0, 10}},
+		{"-0.00012", []byte{0, 2, 0xff, 0xff, 0x40, 0, 0, 5, 0, 1, 0x07, 0xd0}},
+	} {
+		buf, err := m.Encode(pgtype.NumericOID, pgtype.BinaryFormatCode, mustParseNumeric(t, tt.s), nil)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, tt.binary, buf, "%d", i)
+
+		var n pgtype.Numeric
+		err = m.Scan(pgtype.NumericOID, pgtype.BinaryFormatCode, tt.binary, &n)
+		require.NoErrorf(t, err, "%d", i)
+		require.Truef(t, isExpectedEqNumeric(mustParseNumeric(t, tt.s))(n), "%d", i)
+	}
+}
+
+func TestNumericCodecParameterDigitGroups(t *testing.T) {
+	strs := []string{"123456.789", "0.001", "-1234.5", "-100000", "-0.00012", "0"}
+	numerics := make([]pgtype.Numeric, len(strs))
+	for i, s := range strs {
+		numerics[i] = mustParseNumeric(t, s)
+	}
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		for i, n := range numerics {
+			var result string
+			err := conn.QueryRow(ctx, "select $1::numeric::text", n).Scan(&result)
+			require.NoError(t, err)
+			require.Equal(t, strs[i], result)
+		}
+	})
+}
+
+func TestNumericCodecScanSpecialValueLiterals(t *testing.T) {
+	skipCockroachDB(t, "server formats numeric text format differently")
+
+	pgxtest.RunWithQueryExecModes(context.Background(), t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var n pgtype.Numeric
+		err := conn.QueryRow(ctx, "select 'NaN'::numeric").Scan(&n)
+		require.NoError(t, err)
+		require.Equal(t, pgtype.Numeric{NaN: true, Valid: true}, n)
+
+		var f float64
+		err = conn.QueryRow(ctx, "select 'NaN'::numeric").Scan(&f)
+		require.NoError(t, err)
+		require.True(t, math.IsNaN(f))
+	})
+}
+
 func TestNumericFloat64Valuer(t *testing.T) {
 	for i, tt := range []struct {
 		n pgtype.Numeric
@@ -0,0 +1,43 @@
+package pgtype
+
+import (
+	"database/sql/driver"
+)
+
+// OIDAliasCodec is the codec for the PostgreSQL OID alias types such as regtype, regproc, and regclass. In the text
+// format these types are the name of the object (e.g. integer). In the binary format they are the OID of the object.
+//
+// The text format is preferred so by default values are received as names and can be scanned into a string. To
+// receive OIDs, request the binary format for the type (e.g. with pgx.QueryResultFormatsByOID) and scan into a uint32.
+// Parameters may be given as either a name or an OID.
+type OIDAliasCodec struct {
+	Uint32Codec
+}
+
+func (OIDAliasCodec) PreferredFormat() int16 {
+	return TextFormatCode
+}
+
+func (c OIDAliasCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	if format == TextFormatCode {
+		return string(src), nil
+	}
+
+	return c.Uint32Codec.DecodeDatabaseSQLValue(m, oid, format, src)
+}
+
+func (c OIDAliasCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	if format == TextFormatCode {
+		return string(src), nil
+	}
+
+	return c.Uint32Codec.DecodeValue(m, oid, format, src)
+}
@@ -0,0 +1,57 @@
+package pgtype_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDAliasCodecScanName(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var typeName, className string
+		err := conn.QueryRow(ctx, "select 'int4'::regtype, 'pg_class'::regclass").Scan(&typeName, &className)
+		require.NoError(t, err)
+		require.Equal(t, "integer", typeName)
+		require.Equal(t, "pg_class", className)
+
+		var v any
+		err = conn.QueryRow(ctx, "select 'int4'::regtype").Scan(&v)
+		require.NoError(t, err)
+		require.Equal(t, "integer", v)
+	})
+}
+
+func TestOIDAliasCodecScanOID(t *testing.T) {
+	pgxtest.RunWithQueryExecModes(context.Background(), t, defaultConnTestRunner, pgxtest.KnownOIDQueryExecModes, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		resultFormats := pgx.QueryResultFormatsByOID{pgtype.RegtypeOID: pgx.BinaryFormatCode, pgtype.RegprocOID: pgx.BinaryFormatCode}
+
+		var typeOID, procOID uint32
+		err := conn.QueryRow(ctx, "select 'int4'::regtype, 'now'::regproc", resultFormats).Scan(&typeOID, &procOID)
+		require.NoError(t, err)
+		require.EqualValues(t, pgtype.Int4OID, typeOID)
+		require.NotZero(t, procOID)
+
+		var v any
+		err = conn.QueryRow(ctx, "select 'int4'::regtype", resultFormats).Scan(&v)
+		require.NoError(t, err)
+		require.Equal(t, uint32(pgtype.Int4OID), v)
+	})
+}
+
+func TestOIDAliasCodecEncode(t *testing.T) {
+	pgxtest.RunWithQueryExecModes(context.Background(), t, defaultConnTestRunner, pgxtest.KnownOIDQueryExecModes, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var typeName string
+		err := conn.QueryRow(ctx, "select $1::regtype", uint32(pgtype.TextOID)).Scan(&typeName)
+		require.NoError(t, err)
+		require.Equal(t, "text", typeName)
+
+		var oid uint32
+		err = conn.QueryRow(ctx, "select $1::regtype::oid", "int8").Scan(&oid)
+		require.NoError(t, err)
+		require.EqualValues(t, pgtype.Int8OID, oid)
+	})
+}
@@ -189,7 +189,7 @@ func (scanPlanBinaryPathToPathScanner) Scan(src []byte, dst any) error {
 
 	rp := 5
 
-	if 5+pointCount*16 != len(src) {
+	if (len(src)-5)/16 != pointCount || (len(src)-5)%16 != 0 {
 		return fmt.Errorf("invalid length for Path with %d points: %v", pointCount, len(src))
 	}
 
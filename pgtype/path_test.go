@@ -2,10 +2,13 @@ package pgtype_test
 
 import (
 	"context"
+	"encoding/binary"
+	"math"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func isExpectedEqPath(a any) func(any) bool {
@@ -74,3 +77,38 @@ func TestPathTranscode(t *testing.T) {
 		{nil, new(pgtype.Path), isExpectedEqPath(pgtype.Path{})},
 	})
 }
+
+// appendBinaryPoints appends the binary wire format of points to buf.
+func appendBinaryPoints(buf []byte, points ...pgtype.Vec2) []byte {
+	for _, p := range points {
+		buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(p.X))
+		buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(p.Y))
+	}
+	return buf
+}
+
+func TestPathScanBinary(t *testing.T) {
+	m := pgtype.NewMap()
+	points := []pgtype.Vec2{{1, 2}, {3.5, -4}, {0, 6}}
+
+	src := []byte{1}
+	src = binary.BigEndian.AppendUint32(src, uint32(len(points)))
+	src = appendBinaryPoints(src, points...)
+
+	var path pgtype.Path
+	err := m.Scan(pgtype.PathOID, pgtype.BinaryFormatCode, src, &path)
+	require.NoError(t, err)
+	require.Equal(t, pgtype.Path{P: points, Closed: true, Valid: true}, path)
+
+	src[0] = 0
+	err = m.Scan(pgtype.PathOID, pgtype.BinaryFormatCode, src, &path)
+	require.NoError(t, err)
+	require.Equal(t, pgtype.Path{P: points, Closed: false, Valid: true}, path)
+
+	err = m.Scan(pgtype.PathOID, pgtype.BinaryFormatCode, src[:len(src)-1], &path)
+	require.Error(t, err)
+
+	corrupt := append([]byte{0}, binary.BigEndian.AppendUint32(nil, math.MaxUint32)...)
+	err = m.Scan(pgtype.PathOID, pgtype.BinaryFormatCode, corrupt, &path)
+	require.Error(t, err)
+}
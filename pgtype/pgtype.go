@@ -3,6 +3,7 @@ package pgtype
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding"
 	"errors"
 	"fmt"
 	"net"
@@ -19,14 +20,19 @@ const (
 	NameOID                = 19
 	Int8OID                = 20
 	Int2OID                = 21
+	Int2VectorOID          = 22
 	Int4OID                = 23
+	RegprocOID             = 24
 	TextOID                = 25
 	OIDOID                 = 26
 	TIDOID                 = 27
 	XIDOID                 = 28
 	CIDOID                 = 29
+	OIDVectorOID           = 30
 	JSONOID                = 114
 	JSONArrayOID           = 199
+	XMLOID                 = 142
+	XMLArrayOID            = 143
 	PointOID               = 600
 	LsegOID                = 601
 	PathOID                = 602
@@ -40,6 +46,8 @@ const (
 	Float8OID              = 701
 	CircleOID              = 718
 	CircleArrayOID         = 719
+	MoneyOID               = 790
+	MoneyArrayOID          = 791
 	UnknownOID             = 705
 	MacaddrOID             = 829
 	InetOID                = 869
@@ -48,6 +56,7 @@ const (
 	NameArrayOID           = 1003
 	Int2ArrayOID           = 1005
 	Int4ArrayOID           = 1007
+	RegprocArrayOID        = 1008
 	TextArrayOID           = 1009
 	TIDArrayOID            = 1010
 	ByteaArrayOID          = 1001
@@ -81,12 +90,25 @@ const (
 	IntervalOID            = 1186
 	IntervalArrayOID       = 1187
 	NumericArrayOID        = 1231
+	TimetzOID              = 1266
+	TimetzArrayOID         = 1270
 	BitOID                 = 1560
 	BitArrayOID            = 1561
 	VarbitOID              = 1562
 	VarbitArrayOID         = 1563
 	NumericOID             = 1700
+	RegprocedureOID        = 2202
+	RegoperOID             = 2203
+	RegoperatorOID         = 2204
+	RegclassOID            = 2205
+	RegtypeOID             = 2206
+	RegprocedureArrayOID   = 2207
+	RegoperArrayOID        = 2208
+	RegoperatorArrayOID    = 2209
+	RegclassArrayOID       = 2210
+	RegtypeArrayOID        = 2211
 	RecordOID              = 2249
+	VoidOID                = 2278
 	RecordArrayOID         = 2287
 	UUIDOID                = 2950
 	UUIDArrayOID           = 2951
@@ -106,6 +128,10 @@ const (
 	Int8rangeArrayOID      = 3927
 	JSONPathOID            = 4072
 	JSONPathArrayOID       = 4073
+	RegnamespaceOID        = 4089
+	RegnamespaceArrayOID   = 4090
+	RegroleOID             = 4096
+	RegroleArrayOID        = 4097
 	Int4multirangeOID      = 4451
 	NummultirangeOID       = 4532
 	TsmultirangeOID        = 4533
@@ -198,6 +224,8 @@ type Map struct {
 	memoizedScanPlans   map[uint32]map[reflect.Type][2]ScanPlan
 	memoizedEncodePlans map[uint32]map[reflect.Type][2]EncodePlan
 
+	floatDatetimes bool
+
 	// TryWrapEncodePlanFuncs is a slice of functions that will wrap a value that cannot be encoded by the Codec. Every
 	// time a wrapper is found the PlanEncode method will be recursively called with the new value. This allows several layers of wrappers
 	// to be built up. There are default functions placed in this slice by NewMap(). In most cases these functions
@@ -261,6 +289,48 @@ func (m *Map) RegisterType(t *Type) {
 	}
 }
 
+// SetFloatDatetimes sets whether the binary format of timestamp, timestamptz, time, and interval values is float8
+// seconds, as sent by servers built with floating point datetimes (integer_datetimes is off), instead of int64
+// microseconds. pgx sets this when connecting.
+func (m *Map) SetFloatDatetimes(floatDatetimes bool) {
+	m.floatDatetimes = floatDatetimes
+
+	// Invalidated as plans depend on the binary format
+	for k := range m.memoizedScanPlans {
+		delete(m.memoizedScanPlans, k)
+	}
+	for k := range m.memoizedEncodePlans {
+		delete(m.memoizedEncodePlans, k)
+	}
+}
+
+// FloatDatetimes reports whether m uses the floating point datetimes binary format. See SetFloatDatetimes.
+func (m *Map) FloatDatetimes() bool {
+	return m.floatDatetimes
+}
+
+// usesFloatDatetimes is like FloatDatetimes but is safe to call on a nil Map.
+func (m *Map) usesFloatDatetimes() bool {
+	return m != nil && m.floatDatetimes
+}
+
+// RegisterArrayType registers an array type with arrayOID whose elements are of the already registered type with
+// elementOID. The array type is named by prefixing the element type name with an underscore as PostgreSQL does. This
+// allows any type with a registered Codec to be used in an array without building the ArrayCodec manually.
+func (m *Map) RegisterArrayType(arrayOID, elementOID uint32) error {
+	elementType, ok := m.TypeForOID(elementOID)
+	if !ok {
+		return fmt.Errorf("cannot register array type %d: element type %d is not registered", arrayOID, elementOID)
+	}
+
+	m.registerArrayType(arrayOID, elementType)
+	return nil
+}
+
+func (m *Map) registerArrayType(arrayOID uint32, elementType *Type) {
+	m.RegisterType(&Type{Name: "_" + elementType.Name, OID: arrayOID, Codec: &ArrayCodec{ElementType: elementType}})
+}
+
 // RegisterDefaultPgType registers a mapping of a Go type to a PostgreSQL type name. Typically the data type to be
 // encoded or decoded is determined by the PostgreSQL OID. But if the OID of a value to be encoded or decoded is
 // unknown, this additional mapping will be used by TypeForValue to determine a suitable data type.
@@ -385,6 +455,26 @@ func (plan *scanPlanSQLScanner) Scan(src []byte, dst any) error {
 	}
 }
 
+type scanPlanTextUnmarshaler struct{}
+
+func (scanPlanTextUnmarshaler) Scan(src []byte, dst any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", dst)
+	}
+
+	return dst.(encoding.TextUnmarshaler).UnmarshalText(src)
+}
+
+type scanPlanBinaryUnmarshaler struct{}
+
+func (scanPlanBinaryUnmarshaler) Scan(src []byte, dst any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", dst)
+	}
+
+	return dst.(encoding.BinaryUnmarshaler).UnmarshalBinary(src)
+}
+
 type scanPlanString struct{}
 
 func (scanPlanString) Scan(src []byte, dst any) error {
@@ -1111,6 +1201,23 @@ func (m *Map) planScan(oid uint32, formatCode int16, target any) ScanPlan {
 		}
 	}
 
+	// A type implementing encoding.TextUnmarshaler or encoding.BinaryUnmarshaler for the format of the value is given the
+	// raw value. Like sql.Scanner this is checked before m.TryWrapScanPlanFuncs so it takes precedence over the
+	// underlying type. Built-in types such as time.Time and net.IP also implement these interfaces but their encodings
+	// differ from PostgreSQL's so they continue to use their wrappers.
+	if _, _, ok := TryWrapBuiltinTypeScanPlan(target); !ok {
+		switch formatCode {
+		case TextFormatCode:
+			if _, ok := target.(encoding.TextUnmarshaler); ok {
+				return scanPlanTextUnmarshaler{}
+			}
+		case BinaryFormatCode:
+			if _, ok := target.(encoding.BinaryUnmarshaler); ok {
+				return scanPlanBinaryUnmarshaler{}
+			}
+		}
+	}
+
 	for _, f := range m.TryWrapScanPlanFuncs {
 		if wrapperPlan, nextDst, ok := f(target); ok {
 			if nextPlan := m.planScan(oid, formatCode, nextDst); nextPlan != nil {
@@ -1170,6 +1277,12 @@ func codecDecodeToTextFormat(codec Codec, m *Map, oid uint32, format int16, src
 	}
 }
 
+// textEncodePlanWrapper may be implemented by a Codec that needs to see values that bypass it in the text format, such
+// as strings and TextValuers. wrapTextEncodePlan returns plan or a plan that wraps it.
+type textEncodePlanWrapper interface {
+	wrapTextEncodePlan(plan EncodePlan) EncodePlan
+}
+
 // PlanEncode returns an Encode plan for encoding value into PostgreSQL format for oid and format. If no plan can be
 // found then nil is returned.
 func (m *Map) PlanEncode(oid uint32, format int16, value any) EncodePlan {
@@ -1192,11 +1305,22 @@ func (m *Map) PlanEncode(oid uint32, format int16, value any) EncodePlan {
 
 func (m *Map) planEncode(oid uint32, format int16, value any) EncodePlan {
 	if format == TextFormatCode {
+		var plan EncodePlan
 		switch value.(type) {
 		case string:
-			return encodePlanStringToAnyTextFormat{}
+			plan = encodePlanStringToAnyTextFormat{}
 		case TextValuer:
-			return encodePlanTextValuerToAnyTextFormat{}
+			plan = encodePlanTextValuerToAnyTextFormat{}
+		}
+
+		if plan != nil {
+			// Strings bypass the codec in the text format, so give the codec a chance to wrap this plan.
+			if dt, ok := m.TypeForOID(oid); ok {
+				if wrapper, ok := dt.Codec.(textEncodePlanWrapper); ok {
+					return wrapper.wrapTextEncodePlan(plan)
+				}
+			}
+			return plan
 		}
 	}
 
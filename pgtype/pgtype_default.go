@@ -14,6 +14,67 @@ var (
 	defaultMapInitOnce = sync.Once{}
 )
 
+// arrayElementOIDs maps the OID of each builtin array type to the OID of its element type.
+var arrayElementOIDs = map[uint32]uint32{
+	ACLItemArrayOID:      ACLItemOID,
+	BitArrayOID:          BitOID,
+	BoolArrayOID:         BoolOID,
+	BoxArrayOID:          BoxOID,
+	BPCharArrayOID:       BPCharOID,
+	ByteaArrayOID:        ByteaOID,
+	QCharArrayOID:        QCharOID,
+	CIDArrayOID:          CIDOID,
+	CIDRArrayOID:         CIDROID,
+	CircleArrayOID:       CircleOID,
+	DateArrayOID:         DateOID,
+	DaterangeArrayOID:    DaterangeOID,
+	Float4ArrayOID:       Float4OID,
+	Float8ArrayOID:       Float8OID,
+	InetArrayOID:         InetOID,
+	Int2ArrayOID:         Int2OID,
+	Int4ArrayOID:         Int4OID,
+	Int4rangeArrayOID:    Int4rangeOID,
+	Int8ArrayOID:         Int8OID,
+	Int8rangeArrayOID:    Int8rangeOID,
+	IntervalArrayOID:     IntervalOID,
+	JSONArrayOID:         JSONOID,
+	JSONBArrayOID:        JSONBOID,
+	JSONPathArrayOID:     JSONPathOID,
+	LineArrayOID:         LineOID,
+	LsegArrayOID:         LsegOID,
+	MacaddrArrayOID:      MacaddrOID,
+	MoneyArrayOID:        MoneyOID,
+	NameArrayOID:         NameOID,
+	NumericArrayOID:      NumericOID,
+	NumrangeArrayOID:     NumrangeOID,
+	OIDArrayOID:          OIDOID,
+	PathArrayOID:         PathOID,
+	PointArrayOID:        PointOID,
+	PolygonArrayOID:      PolygonOID,
+	RecordArrayOID:       RecordOID,
+	RegclassArrayOID:     RegclassOID,
+	RegnamespaceArrayOID: RegnamespaceOID,
+	RegoperArrayOID:      RegoperOID,
+	RegoperatorArrayOID:  RegoperatorOID,
+	RegprocArrayOID:      RegprocOID,
+	RegprocedureArrayOID: RegprocedureOID,
+	RegroleArrayOID:      RegroleOID,
+	RegtypeArrayOID:      RegtypeOID,
+	TextArrayOID:         TextOID,
+	TIDArrayOID:          TIDOID,
+	TimeArrayOID:         TimeOID,
+	TimestampArrayOID:    TimestampOID,
+	TimestamptzArrayOID:  TimestamptzOID,
+	TimetzArrayOID:       TimetzOID,
+	TsrangeArrayOID:      TsrangeOID,
+	TstzrangeArrayOID:    TstzrangeOID,
+	UUIDArrayOID:         UUIDOID,
+	VarbitArrayOID:       VarbitOID,
+	VarcharArrayOID:      VarcharOID,
+	XIDArrayOID:          XIDOID,
+	XMLArrayOID:          XMLOID,
+}
+
 func initDefaultMap() {
 	defaultMap = &Map{
 		oidToType:         make(map[uint32]*Type),
@@ -70,6 +131,7 @@ func initDefaultMap() {
 	defaultMap.RegisterType(&Type{Name: "line", OID: LineOID, Codec: LineCodec{}})
 	defaultMap.RegisterType(&Type{Name: "lseg", OID: LsegOID, Codec: LsegCodec{}})
 	defaultMap.RegisterType(&Type{Name: "macaddr", OID: MacaddrOID, Codec: MacaddrCodec{}})
+	defaultMap.RegisterType(&Type{Name: "money", OID: MoneyOID, Codec: MoneyCodec{}})
 	defaultMap.RegisterType(&Type{Name: "name", OID: NameOID, Codec: TextCodec{}})
 	defaultMap.RegisterType(&Type{Name: "numeric", OID: NumericOID, Codec: NumericCodec{}})
 	defaultMap.RegisterType(&Type{Name: "oid", OID: OIDOID, Codec: Uint32Codec{}})
@@ -77,16 +139,27 @@ func initDefaultMap() {
 	defaultMap.RegisterType(&Type{Name: "point", OID: PointOID, Codec: PointCodec{}})
 	defaultMap.RegisterType(&Type{Name: "polygon", OID: PolygonOID, Codec: PolygonCodec{}})
 	defaultMap.RegisterType(&Type{Name: "record", OID: RecordOID, Codec: RecordCodec{}})
+	defaultMap.RegisterType(&Type{Name: "regclass", OID: RegclassOID, Codec: OIDAliasCodec{}})
+	defaultMap.RegisterType(&Type{Name: "regnamespace", OID: RegnamespaceOID, Codec: OIDAliasCodec{}})
+	defaultMap.RegisterType(&Type{Name: "regoper", OID: RegoperOID, Codec: OIDAliasCodec{}})
+	defaultMap.RegisterType(&Type{Name: "regoperator", OID: RegoperatorOID, Codec: OIDAliasCodec{}})
+	defaultMap.RegisterType(&Type{Name: "regproc", OID: RegprocOID, Codec: OIDAliasCodec{}})
+	defaultMap.RegisterType(&Type{Name: "regprocedure", OID: RegprocedureOID, Codec: OIDAliasCodec{}})
+	defaultMap.RegisterType(&Type{Name: "regrole", OID: RegroleOID, Codec: OIDAliasCodec{}})
+	defaultMap.RegisterType(&Type{Name: "regtype", OID: RegtypeOID, Codec: OIDAliasCodec{}})
 	defaultMap.RegisterType(&Type{Name: "text", OID: TextOID, Codec: TextCodec{}})
 	defaultMap.RegisterType(&Type{Name: "tid", OID: TIDOID, Codec: TIDCodec{}})
 	defaultMap.RegisterType(&Type{Name: "time", OID: TimeOID, Codec: TimeCodec{}})
 	defaultMap.RegisterType(&Type{Name: "timestamp", OID: TimestampOID, Codec: TimestampCodec{}})
 	defaultMap.RegisterType(&Type{Name: "timestamptz", OID: TimestamptzOID, Codec: TimestamptzCodec{}})
+	defaultMap.RegisterType(&Type{Name: "timetz", OID: TimetzOID, Codec: TimetzCodec{}})
 	defaultMap.RegisterType(&Type{Name: "unknown", OID: UnknownOID, Codec: TextCodec{}})
 	defaultMap.RegisterType(&Type{Name: "uuid", OID: UUIDOID, Codec: UUIDCodec{}})
 	defaultMap.RegisterType(&Type{Name: "varbit", OID: VarbitOID, Codec: BitsCodec{}})
 	defaultMap.RegisterType(&Type{Name: "varchar", OID: VarcharOID, Codec: TextCodec{}})
+	defaultMap.RegisterType(&Type{Name: "void", OID: VoidOID, Codec: VoidCodec{}})
 	defaultMap.RegisterType(&Type{Name: "xid", OID: XIDOID, Codec: Uint32Codec{}})
+	defaultMap.RegisterType(&Type{Name: "xml", OID: XMLOID, Codec: XMLCodec{}})
 
 	// Range types
 	defaultMap.RegisterType(&Type{Name: "daterange", OID: DaterangeOID, Codec: &RangeCodec{ElementType: defaultMap.oidToType[DateOID]}})
@@ -104,53 +177,14 @@ func initDefaultMap() {
 	defaultMap.RegisterType(&Type{Name: "tsmultirange", OID: TsmultirangeOID, Codec: &MultirangeCodec{ElementType: defaultMap.oidToType[TsrangeOID]}})
 	defaultMap.RegisterType(&Type{Name: "tstzmultirange", OID: TstzmultirangeOID, Codec: &MultirangeCodec{ElementType: defaultMap.oidToType[TstzrangeOID]}})
 
+	// Vector types
+	defaultMap.RegisterType(&Type{Name: "int2vector", OID: Int2VectorOID, Codec: &VectorCodec{ElementType: defaultMap.oidToType[Int2OID]}})
+	defaultMap.RegisterType(&Type{Name: "oidvector", OID: OIDVectorOID, Codec: &VectorCodec{ElementType: defaultMap.oidToType[OIDOID]}})
+
 	// Array types
-	defaultMap.RegisterType(&Type{Name: "_aclitem", OID: ACLItemArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[ACLItemOID]}})
-	defaultMap.RegisterType(&Type{Name: "_bit", OID: BitArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[BitOID]}})
-	defaultMap.RegisterType(&Type{Name: "_bool", OID: BoolArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[BoolOID]}})
-	defaultMap.RegisterType(&Type{Name: "_box", OID: BoxArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[BoxOID]}})
-	defaultMap.RegisterType(&Type{Name: "_bpchar", OID: BPCharArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[BPCharOID]}})
-	defaultMap.RegisterType(&Type{Name: "_bytea", OID: ByteaArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[ByteaOID]}})
-	defaultMap.RegisterType(&Type{Name: "_char", OID: QCharArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[QCharOID]}})
-	defaultMap.RegisterType(&Type{Name: "_cid", OID: CIDArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[CIDOID]}})
-	defaultMap.RegisterType(&Type{Name: "_cidr", OID: CIDRArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[CIDROID]}})
-	defaultMap.RegisterType(&Type{Name: "_circle", OID: CircleArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[CircleOID]}})
-	defaultMap.RegisterType(&Type{Name: "_date", OID: DateArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[DateOID]}})
-	defaultMap.RegisterType(&Type{Name: "_daterange", OID: DaterangeArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[DaterangeOID]}})
-	defaultMap.RegisterType(&Type{Name: "_float4", OID: Float4ArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[Float4OID]}})
-	defaultMap.RegisterType(&Type{Name: "_float8", OID: Float8ArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[Float8OID]}})
-	defaultMap.RegisterType(&Type{Name: "_inet", OID: InetArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[InetOID]}})
-	defaultMap.RegisterType(&Type{Name: "_int2", OID: Int2ArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[Int2OID]}})
-	defaultMap.RegisterType(&Type{Name: "_int4", OID: Int4ArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[Int4OID]}})
-	defaultMap.RegisterType(&Type{Name: "_int4range", OID: Int4rangeArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[Int4rangeOID]}})
-	defaultMap.RegisterType(&Type{Name: "_int8", OID: Int8ArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[Int8OID]}})
-	defaultMap.RegisterType(&Type{Name: "_int8range", OID: Int8rangeArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[Int8rangeOID]}})
-	defaultMap.RegisterType(&Type{Name: "_interval", OID: IntervalArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[IntervalOID]}})
-	defaultMap.RegisterType(&Type{Name: "_json", OID: JSONArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[JSONOID]}})
-	defaultMap.RegisterType(&Type{Name: "_jsonb", OID: JSONBArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[JSONBOID]}})
-	defaultMap.RegisterType(&Type{Name: "_jsonpath", OID: JSONPathArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[JSONPathOID]}})
-	defaultMap.RegisterType(&Type{Name: "_line", OID: LineArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[LineOID]}})
-	defaultMap.RegisterType(&Type{Name: "_lseg", OID: LsegArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[LsegOID]}})
-	defaultMap.RegisterType(&Type{Name: "_macaddr", OID: MacaddrArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[MacaddrOID]}})
-	defaultMap.RegisterType(&Type{Name: "_name", OID: NameArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[NameOID]}})
-	defaultMap.RegisterType(&Type{Name: "_numeric", OID: NumericArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[NumericOID]}})
-	defaultMap.RegisterType(&Type{Name: "_numrange", OID: NumrangeArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[NumrangeOID]}})
-	defaultMap.RegisterType(&Type{Name: "_oid", OID: OIDArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[OIDOID]}})
-	defaultMap.RegisterType(&Type{Name: "_path", OID: PathArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[PathOID]}})
-	defaultMap.RegisterType(&Type{Name: "_point", OID: PointArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[PointOID]}})
-	defaultMap.RegisterType(&Type{Name: "_polygon", OID: PolygonArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[PolygonOID]}})
-	defaultMap.RegisterType(&Type{Name: "_record", OID: RecordArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[RecordOID]}})
-	defaultMap.RegisterType(&Type{Name: "_text", OID: TextArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[TextOID]}})
-	defaultMap.RegisterType(&Type{Name: "_tid", OID: TIDArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[TIDOID]}})
-	defaultMap.RegisterType(&Type{Name: "_time", OID: TimeArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[TimeOID]}})
-	defaultMap.RegisterType(&Type{Name: "_timestamp", OID: TimestampArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[TimestampOID]}})
-	defaultMap.RegisterType(&Type{Name: "_timestamptz", OID: TimestamptzArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[TimestamptzOID]}})
-	defaultMap.RegisterType(&Type{Name: "_tsrange", OID: TsrangeArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[TsrangeOID]}})
-	defaultMap.RegisterType(&Type{Name: "_tstzrange", OID: TstzrangeArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[TstzrangeOID]}})
-	defaultMap.RegisterType(&Type{Name: "_uuid", OID: UUIDArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[UUIDOID]}})
-	defaultMap.RegisterType(&Type{Name: "_varbit", OID: VarbitArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[VarbitOID]}})
-	defaultMap.RegisterType(&Type{Name: "_varchar", OID: VarcharArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[VarcharOID]}})
-	defaultMap.RegisterType(&Type{Name: "_xid", OID: XIDArrayOID, Codec: &ArrayCodec{ElementType: defaultMap.oidToType[XIDOID]}})
+	for arrayOID, elementOID := range arrayElementOIDs {
+		defaultMap.registerArrayType(arrayOID, defaultMap.oidToType[elementOID])
+	}
 
 	// Integer types that directly map to a PostgreSQL type
 	registerDefaultPgTypeVariants[int16](defaultMap, "int2")
@@ -213,6 +247,7 @@ func initDefaultMap() {
 	registerDefaultPgTypeVariants[Time](defaultMap, "time")
 	registerDefaultPgTypeVariants[Timestamp](defaultMap, "timestamp")
 	registerDefaultPgTypeVariants[Timestamptz](defaultMap, "timestamptz")
+	registerDefaultPgTypeVariants[Timetz](defaultMap, "timetz")
 	registerDefaultPgTypeVariants[Range[Timestamp]](defaultMap, "tsrange")
 	registerDefaultPgTypeVariants[Multirange[Range[Timestamp]]](defaultMap, "tsmultirange")
 	registerDefaultPgTypeVariants[Range[Timestamptz]](defaultMap, "tstzrange")
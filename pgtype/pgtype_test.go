@@ -4,14 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"os"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -130,6 +134,28 @@ func (f driverValuerFunc) Value() (driver.Value, error) {
 	return f()
 }
 
+func TestMapRegisterArrayType(t *testing.T) {
+	m := pgtype.NewMap()
+	elementOID := uint32(999998)
+	arrayOID := uint32(999999)
+	m.RegisterType(&pgtype.Type{Name: "myint", OID: elementOID, Codec: pgtype.Int4Codec{}})
+
+	err := m.RegisterArrayType(arrayOID, elementOID)
+	require.NoError(t, err)
+
+	arrayType, ok := m.TypeForOID(arrayOID)
+	require.True(t, ok)
+	assert.Equal(t, "_myint", arrayType.Name)
+
+	var got []int32
+	err = m.Scan(arrayOID, pgx.TextFormatCode, []byte("{1,2,3}"), &got)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{1, 2, 3}, got)
+
+	err = m.RegisterArrayType(arrayOID+1, elementOID+2)
+	require.Error(t, err)
+}
+
 func TestMapScanNilIsNoOp(t *testing.T) {
 	m := pgtype.NewMap()
 
@@ -232,6 +258,87 @@ func TestMapScanUnregisteredOIDIntoRenamedStringSQLScanner(t *testing.T) {
 	assert.Equal(t, "scanned", string(s))
 }
 
+// emailAddress is a renamed string that validates its value when unmarshaled.
+type emailAddress string
+
+func (e *emailAddress) UnmarshalText(text []byte) error {
+	if !strings.Contains(string(text), "@") {
+		return fmt.Errorf("invalid email address: %s", text)
+	}
+	*e = emailAddress(strings.ToLower(string(text)))
+	return nil
+}
+
+// point2D is unmarshaled from the binary format of the PostgreSQL point type.
+type point2D struct {
+	X, Y float64
+}
+
+func (p *point2D) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return fmt.Errorf("invalid length for point: %d", len(data))
+	}
+	p.X = math.Float64frombits(binary.BigEndian.Uint64(data))
+	p.Y = math.Float64frombits(binary.BigEndian.Uint64(data[8:]))
+	return nil
+}
+
+func TestMapScanTextUnmarshaler(t *testing.T) {
+	m := pgtype.NewMap()
+
+	var e emailAddress
+	err := m.Scan(pgtype.TextOID, pgx.TextFormatCode, []byte("Alice@Example.com"), &e)
+	require.NoError(t, err)
+	require.Equal(t, emailAddress("alice@example.com"), e)
+
+	err = m.Scan(pgtype.TextOID, pgx.TextFormatCode, []byte("alice"), &e)
+	require.ErrorContains(t, err, "invalid email address")
+
+	err = m.Scan(pgtype.TextOID, pgx.TextFormatCode, nil, &e)
+	require.Error(t, err)
+
+	var pe *emailAddress
+	err = m.Scan(pgtype.TextOID, pgx.TextFormatCode, nil, &pe)
+	require.NoError(t, err)
+	require.Nil(t, pe)
+
+	err = m.Scan(pgtype.TextOID, pgx.TextFormatCode, []byte("bob@example.com"), &pe)
+	require.NoError(t, err)
+	require.Equal(t, emailAddress("bob@example.com"), *pe)
+
+	// Built-in types that implement encoding.TextUnmarshaler continue to use their own scan plans.
+	var ip net.IP
+	err = m.Scan(pgtype.InetOID, pgx.TextFormatCode, []byte("127.0.0.1/32"), &ip)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1", ip.String())
+}
+
+func TestMapScanBinaryUnmarshaler(t *testing.T) {
+	m := pgtype.NewMap()
+
+	src, err := m.Encode(pgtype.PointOID, pgx.BinaryFormatCode, pgtype.Point{P: pgtype.Vec2{X: 1.5, Y: -2}, Valid: true}, nil)
+	require.NoError(t, err)
+
+	var p point2D
+	err = m.Scan(unregisteredOID, pgx.BinaryFormatCode, src, &p)
+	require.NoError(t, err)
+	require.Equal(t, point2D{X: 1.5, Y: -2}, p)
+}
+
+func TestConnScanTextUnmarshaler(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var e emailAddress
+		err := conn.QueryRow(ctx, "select 'Alice@Example.com'::text", pgx.QueryResultFormats{pgx.TextFormatCode}).Scan(&e)
+		require.NoError(t, err)
+		require.Equal(t, emailAddress("alice@example.com"), e)
+
+		var p point2D
+		err = conn.QueryRow(ctx, "select point(1.5, -2)", pgx.QueryResultFormats{pgx.BinaryFormatCode}).Scan(&p)
+		require.NoError(t, err)
+		require.Equal(t, point2D{X: 1.5, Y: -2}, p)
+	})
+}
+
 type pgCustomInt int64
 
 func (ci *pgCustomInt) Scan(src interface{}) error {
@@ -388,6 +495,40 @@ func TestMapEncodeBinaryFormatDatabaseValuerThatReturnsString(t *testing.T) {
 	require.Equal(t, []byte{0, 0, 0, 42}, buf)
 }
 
+func TestMapEncodeDatabaseValuerDriverValueKinds(t *testing.T) {
+	m := pgtype.NewMap()
+	ts := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	tsBuf, err := m.Encode(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, ts, nil)
+	require.NoError(t, err)
+
+	for i, tt := range []struct {
+		value    driver.Value
+		oid      uint32
+		expected []byte
+	}{
+		{int64(42), pgtype.Int8OID, []byte{0, 0, 0, 0, 0, 0, 0, 42}},
+		{float64(1.5), pgtype.Float8OID, []byte{0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{true, pgtype.BoolOID, []byte{1}},
+		{[]byte{1, 2, 3}, pgtype.ByteaOID, []byte{1, 2, 3}},
+		{"foo", pgtype.TextOID, []byte("foo")},
+		{ts, pgtype.TimestamptzOID, tsBuf},
+		{nil, pgtype.Int8OID, nil},
+	} {
+		src := driverValuerFunc(func() (driver.Value, error) { return tt.value, nil })
+		buf, err := m.Encode(tt.oid, pgtype.BinaryFormatCode, src, nil)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, buf, "%d", i)
+	}
+}
+
+func TestMapEncodeDatabaseValuerError(t *testing.T) {
+	m := pgtype.NewMap()
+	valueErr := errors.New("value failed")
+	src := driverValuerFunc(func() (driver.Value, error) { return nil, valueErr })
+	_, err := m.Encode(pgtype.Int8OID, pgtype.BinaryFormatCode, src, nil)
+	require.ErrorIs(t, err, valueErr)
+}
+
 // https://github.com/jackc/pgx/issues/1445
 func TestMapEncodeDatabaseValuerThatReturnsStringIntoUnregisteredTypeTextFormat(t *testing.T) {
 	m := pgtype.NewMap()
@@ -172,7 +172,7 @@ func (scanPlanBinaryPolygonToPolygonScanner) Scan(src []byte, dst any) error {
 	pointCount := int(binary.BigEndian.Uint32(src))
 	rp := 4
 
-	if 4+pointCount*16 != len(src) {
+	if (len(src)-4)/16 != pointCount || (len(src)-4)%16 != 0 {
 		return fmt.Errorf("invalid length for Polygon with %d points: %v", pointCount, len(src))
 	}
 
@@ -2,10 +2,13 @@ package pgtype_test
 
 import (
 	"context"
+	"encoding/binary"
+	"math"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func isExpectedEqPolygon(a any) func(any) bool {
@@ -57,3 +60,24 @@ func TestPolygonTranscode(t *testing.T) {
 		{nil, new(pgtype.Polygon), isExpectedEqPolygon(pgtype.Polygon{})},
 	})
 }
+
+func TestPolygonScanBinary(t *testing.T) {
+	m := pgtype.NewMap()
+	points := []pgtype.Vec2{{1, 2}, {3.5, -4}, {0, 6}}
+
+	src := binary.BigEndian.AppendUint32(nil, uint32(len(points)))
+	src = appendBinaryPoints(src, points...)
+
+	var polygon pgtype.Polygon
+	err := m.Scan(pgtype.PolygonOID, pgtype.BinaryFormatCode, src, &polygon)
+	require.NoError(t, err)
+	require.Equal(t, pgtype.Polygon{P: points, Valid: true}, polygon)
+
+	err = m.Scan(pgtype.PolygonOID, pgtype.BinaryFormatCode, src[:len(src)-1], &polygon)
+	require.Error(t, err)
+
+	corrupt := binary.BigEndian.AppendUint32(nil, math.MaxUint32)
+	corrupt = appendBinaryPoints(corrupt, points...)
+	err = m.Scan(pgtype.PolygonOID, pgtype.BinaryFormatCode, corrupt, &polygon)
+	require.Error(t, err)
+}
@@ -136,6 +136,53 @@ func TestTextCodecACLItemRoleWithSpecialCharacters(t *testing.T) {
 	})
 }
 
+func TestTextCodecACLItemArray(t *testing.T) {
+	ctr := defaultConnTestRunner
+	ctr.AfterConnect = func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does not support type aclitem")
+	}
+
+	ctr.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var acl []string
+		err := conn.QueryRow(ctx, `select '{postgres=arwdDxt/postgres,=r/postgres}'::aclitem[]`).Scan(&acl)
+		require.NoError(t, err)
+		require.Equal(t, []string{"postgres=arwdDxt/postgres", "=r/postgres"}, acl)
+
+		rows, err := conn.Query(ctx, `select relacl from pg_class where relacl is not null limit 5`)
+		require.NoError(t, err)
+		acls, err := pgx.CollectRows(rows, pgx.RowTo[[]string])
+		require.NoError(t, err)
+		for _, acl := range acls {
+			require.NotEmpty(t, acl)
+			for _, item := range acl {
+				require.Contains(t, item, "=")
+			}
+		}
+	})
+}
+
+func TestTextCodecACLItemArrayScanText(t *testing.T) {
+	m := pgtype.NewMap()
+	src := []byte(`{postgres=arwdDxt/postgres,=r/postgres,"x=r/\" tricky \""}`)
+	expected := []string{"postgres=arwdDxt/postgres", "=r/postgres", `x=r/" tricky "`}
+
+	var strs []string
+	err := m.Scan(pgtype.ACLItemArrayOID, pgtype.TextFormatCode, src, &strs)
+	require.NoError(t, err)
+	require.Equal(t, expected, strs)
+
+	var texts []pgtype.Text
+	err = m.Scan(pgtype.ACLItemArrayOID, pgtype.TextFormatCode, src, &texts)
+	require.NoError(t, err)
+	require.Len(t, texts, 3)
+	require.Equal(t, pgtype.Text{String: expected[2], Valid: true}, texts[2])
+
+	var v any
+	err = m.Scan(pgtype.ACLItemArrayOID, pgtype.TextFormatCode, src, &v)
+	require.NoError(t, err)
+	require.Equal(t, []any{expected[0], expected[1], expected[2]}, v)
+}
+
 func TestTextMarshalJSON(t *testing.T) {
 	successfulTests := []struct {
 		source pgtype.Text
@@ -26,9 +26,8 @@ type TIDValuer interface {
 //
 // it is the data type of the ctid hidden system column.
 //
-// It is currently implemented as a pair unsigned two byte integers.
-// Its conversion functions can be found in src/backend/utils/adt/tid.c
-// in the PostgreSQL sources.
+// It is a four byte block number and a two byte offset number within that block. The text format is
+// (block,offset). Its conversion functions can be found in src/backend/utils/adt/tid.c in the PostgreSQL sources.
 type TID struct {
 	BlockNumber  uint32
 	OffsetNumber uint16
@@ -205,6 +204,10 @@ func (scanPlanTextAnyToTIDScanner) Scan(src []byte, dst any) error {
 		return fmt.Errorf("invalid length for tid: %v", len(src))
 	}
 
+	if src[0] != '(' || src[len(src)-1] != ')' {
+		return fmt.Errorf("invalid format for tid")
+	}
+
 	block, offset, found := strings.Cut(string(src[1:len(src)-1]), ",")
 	if !found {
 		return fmt.Errorf("invalid format for tid")
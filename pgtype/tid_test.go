@@ -4,8 +4,11 @@ import (
 	"context"
 	"testing"
 
+	pgx "github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTIDCodec(t *testing.T) {
@@ -36,3 +39,54 @@ func TestTIDCodec(t *testing.T) {
 		{nil, new(pgtype.TID), isExpectedEq(pgtype.TID{})},
 	})
 }
+
+func TestTIDScan(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for i, tt := range []struct {
+		format   int16
+		src      []byte
+		expected pgtype.TID
+	}{
+		{pgtype.TextFormatCode, []byte("(0,1)"), pgtype.TID{BlockNumber: 0, OffsetNumber: 1, Valid: true}},
+		{pgtype.TextFormatCode, []byte("(4294967295,65535)"), pgtype.TID{BlockNumber: 4294967295, OffsetNumber: 65535, Valid: true}},
+		{pgtype.BinaryFormatCode, []byte{0, 0, 0, 42, 0, 43}, pgtype.TID{BlockNumber: 42, OffsetNumber: 43, Valid: true}},
+		{pgtype.BinaryFormatCode, []byte{255, 255, 255, 255, 255, 255}, pgtype.TID{BlockNumber: 4294967295, OffsetNumber: 65535, Valid: true}},
+	} {
+		var tid pgtype.TID
+		err := m.Scan(pgtype.TIDOID, tt.format, tt.src, &tid)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, tid, "%d", i)
+	}
+
+	for i, src := range []string{"0,1", "[0,1]", "(0;1)", "(4294967296,1)", "(0,65536)", "(-1,1)"} {
+		var tid pgtype.TID
+		err := m.Scan(pgtype.TIDOID, pgtype.TextFormatCode, []byte(src), &tid)
+		require.Errorf(t, err, "%d", i)
+	}
+
+	var tid pgtype.TID
+	err := m.Scan(pgtype.TIDOID, pgtype.BinaryFormatCode, []byte{0, 0, 0, 42, 0}, &tid)
+	require.Error(t, err)
+}
+
+func TestTIDCodecScanCtid(t *testing.T) {
+	skipCockroachDB(t, "Server does not support type tid")
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		_, err := conn.Exec(ctx, "create temporary table tid_test(n int4)")
+		require.NoError(t, err)
+		_, err = conn.Exec(ctx, "insert into tid_test select generate_series(1, 3)")
+		require.NoError(t, err)
+
+		rows, err := conn.Query(ctx, "select ctid from tid_test order by n")
+		require.NoError(t, err)
+		tids, err := pgx.CollectRows(rows, pgx.RowTo[pgtype.TID])
+		require.NoError(t, err)
+		require.Equal(t, []pgtype.TID{
+			{BlockNumber: 0, OffsetNumber: 1, Valid: true},
+			{BlockNumber: 0, OffsetNumber: 2, Valid: true},
+			{BlockNumber: 0, OffsetNumber: 3, Valid: true},
+		}, tids)
+	})
+}
@@ -2,11 +2,8 @@ package pgtype
 
 import (
 	"database/sql/driver"
-	"encoding/binary"
 	"fmt"
 	"strconv"
-
-	"github.com/jackc/pgx/v5/internal/pgio"
 )
 
 type TimeScanner interface {
@@ -81,7 +78,7 @@ func (TimeCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodeP
 
 	switch format {
 	case BinaryFormatCode:
-		return encodePlanTimeCodecBinary{}
+		return encodePlanTimeCodecBinary{floatDatetimes: m.usesFloatDatetimes()}
 	case TextFormatCode:
 		return encodePlanTimeCodecText{}
 	}
@@ -89,9 +86,9 @@ func (TimeCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodeP
 	return nil
 }
 
-type encodePlanTimeCodecBinary struct{}
+type encodePlanTimeCodecBinary struct{ floatDatetimes bool }
 
-func (encodePlanTimeCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
+func (plan encodePlanTimeCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
 	t, err := value.(TimeValuer).TimeValue()
 	if err != nil {
 		return nil, err
@@ -101,7 +98,7 @@ func (encodePlanTimeCodecBinary) Encode(value any, buf []byte) (newBuf []byte, e
 		return nil, nil
 	}
 
-	return pgio.AppendInt64(buf, t.Microseconds), nil
+	return appendDatetimeMicroseconds(buf, t.Microseconds, plan.floatDatetimes), nil
 }
 
 type encodePlanTimeCodecText struct{}
@@ -135,7 +132,7 @@ func (TimeCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan
 	case BinaryFormatCode:
 		switch target.(type) {
 		case TimeScanner:
-			return scanPlanBinaryTimeToTimeScanner{}
+			return scanPlanBinaryTimeToTimeScanner{floatDatetimes: m.usesFloatDatetimes()}
 		}
 	case TextFormatCode:
 		switch target.(type) {
@@ -147,9 +144,9 @@ func (TimeCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan
 	return nil
 }
 
-type scanPlanBinaryTimeToTimeScanner struct{}
+type scanPlanBinaryTimeToTimeScanner struct{ floatDatetimes bool }
 
-func (scanPlanBinaryTimeToTimeScanner) Scan(src []byte, dst any) error {
+func (plan scanPlanBinaryTimeToTimeScanner) Scan(src []byte, dst any) error {
 	scanner := (dst).(TimeScanner)
 
 	if src == nil {
@@ -160,7 +157,7 @@ func (scanPlanBinaryTimeToTimeScanner) Scan(src []byte, dst any) error {
 		return fmt.Errorf("invalid length for time: %v", len(src))
 	}
 
-	usec := int64(binary.BigEndian.Uint64(src))
+	usec := readDatetimeMicroseconds(src, plan.floatDatetimes)
 
 	return scanner.ScanTime(Time{Microseconds: usec, Valid: true})
 }
@@ -2,11 +2,14 @@ package pgtype_test
 
 import (
 	"context"
+	"encoding/binary"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTimeCodec(t *testing.T) {
@@ -45,3 +48,34 @@ func TestTimeCodec(t *testing.T) {
 		{nil, new(pgtype.Time), isExpectedEq(pgtype.Time{})},
 	})
 }
+
+func TestTimeCodecBinaryFloatDatetimes(t *testing.T) {
+	for _, tt := range []struct {
+		value        pgtype.Time
+		integerValue int64
+		floatValue   float64
+	}{
+		{pgtype.Time{Microseconds: 0, Valid: true}, 0, 0},
+		{pgtype.Time{Microseconds: 45296500000, Valid: true}, 45296500000, 45296.5},
+		{pgtype.Time{Microseconds: 86399999999, Valid: true}, 86399999999, 86399.999999},
+	} {
+		for _, floatDatetimes := range []bool{false, true} {
+			m := pgtype.NewMap()
+			m.SetFloatDatetimes(floatDatetimes)
+
+			src := binary.BigEndian.AppendUint64(nil, uint64(tt.integerValue))
+			if floatDatetimes {
+				src = binary.BigEndian.AppendUint64(nil, math.Float64bits(tt.floatValue))
+			}
+
+			var tm pgtype.Time
+			err := m.Scan(pgtype.TimeOID, pgtype.BinaryFormatCode, src, &tm)
+			require.NoErrorf(t, err, "%v %v", tt.value, floatDatetimes)
+			require.Equalf(t, tt.value, tm, "%v %v", tt.value, floatDatetimes)
+
+			buf, err := m.Encode(pgtype.TimeOID, pgtype.BinaryFormatCode, tt.value, nil)
+			require.NoErrorf(t, err, "%v %v", tt.value, floatDatetimes)
+			require.Equalf(t, src, buf, "%v %v", tt.value, floatDatetimes)
+		}
+	}
+}
@@ -2,13 +2,10 @@ package pgtype
 
 import (
 	"database/sql/driver"
-	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
-
-	"github.com/jackc/pgx/v5/internal/pgio"
 )
 
 const pgTimestampFormat = "2006-01-02 15:04:05.999999999"
@@ -116,7 +113,14 @@ func (ts *Timestamp) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-type TimestampCodec struct{}
+type TimestampCodec struct {
+	// ScanLocation is the location that scanned timestamp values are assumed to be in. Unlike
+	// TimestamptzCodec.ScanLocation this does change the instant in time that the scanned value represents: the wall
+	// clock time stored in the database is interpreted in ScanLocation. A wall clock time that does not exist or is
+	// ambiguous in ScanLocation because of a daylight saving time transition is normalized as described by time.Date. If
+	// nil, values are returned in UTC.
+	ScanLocation *time.Location
+}
 
 func (TimestampCodec) FormatSupported(format int16) bool {
 	return format == TextFormatCode || format == BinaryFormatCode
@@ -133,7 +137,7 @@ func (TimestampCodec) PlanEncode(m *Map, oid uint32, format int16, value any) En
 
 	switch format {
 	case BinaryFormatCode:
-		return encodePlanTimestampCodecBinary{}
+		return encodePlanTimestampCodecBinary{floatDatetimes: m.usesFloatDatetimes()}
 	case TextFormatCode:
 		return encodePlanTimestampCodecText{}
 	}
@@ -141,9 +145,9 @@ func (TimestampCodec) PlanEncode(m *Map, oid uint32, format int16, value any) En
 	return nil
 }
 
-type encodePlanTimestampCodecBinary struct{}
+type encodePlanTimestampCodecBinary struct{ floatDatetimes bool }
 
-func (encodePlanTimestampCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
+func (plan encodePlanTimestampCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
 	ts, err := value.(TimestampValuer).TimestampValue()
 	if err != nil {
 		return nil, err
@@ -165,7 +169,7 @@ func (encodePlanTimestampCodecBinary) Encode(value any, buf []byte) (newBuf []by
 		microsecSinceY2K = negativeInfinityMicrosecondOffset
 	}
 
-	buf = pgio.AppendInt64(buf, microsecSinceY2K)
+	buf = appendDatetimeMicroseconds(buf, microsecSinceY2K, plan.floatDatetimes)
 
 	return buf, nil
 }
@@ -212,6 +216,11 @@ func (encodePlanTimestampCodecText) Encode(value any, buf []byte) (newBuf []byte
 	return buf, nil
 }
 
+// inLocationWallClock returns the time with the same wall clock time as t in loc.
+func inLocationWallClock(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
 func discardTimeZone(t time.Time) time.Time {
 	if t.Location() != time.UTC {
 		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
@@ -220,27 +229,30 @@ func discardTimeZone(t time.Time) time.Time {
 	return t
 }
 
-func (TimestampCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+func (c TimestampCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
 
 	switch format {
 	case BinaryFormatCode:
 		switch target.(type) {
 		case TimestampScanner:
-			return scanPlanBinaryTimestampToTimestampScanner{}
+			return scanPlanBinaryTimestampToTimestampScanner{location: c.ScanLocation, floatDatetimes: m.usesFloatDatetimes()}
 		}
 	case TextFormatCode:
 		switch target.(type) {
 		case TimestampScanner:
-			return scanPlanTextTimestampToTimestampScanner{}
+			return scanPlanTextTimestampToTimestampScanner{location: c.ScanLocation}
 		}
 	}
 
 	return nil
 }
 
-type scanPlanBinaryTimestampToTimestampScanner struct{}
+type scanPlanBinaryTimestampToTimestampScanner struct {
+	location       *time.Location
+	floatDatetimes bool
+}
 
-func (scanPlanBinaryTimestampToTimestampScanner) Scan(src []byte, dst any) error {
+func (plan scanPlanBinaryTimestampToTimestampScanner) Scan(src []byte, dst any) error {
 	scanner := (dst).(TimestampScanner)
 
 	if src == nil {
@@ -252,7 +264,7 @@ func (scanPlanBinaryTimestampToTimestampScanner) Scan(src []byte, dst any) error
 	}
 
 	var ts Timestamp
-	microsecSinceY2K := int64(binary.BigEndian.Uint64(src))
+	microsecSinceY2K := readDatetimeMicroseconds(src, plan.floatDatetimes)
 
 	switch microsecSinceY2K {
 	case infinityMicrosecondOffset:
@@ -264,15 +276,18 @@ func (scanPlanBinaryTimestampToTimestampScanner) Scan(src []byte, dst any) error
 			microsecFromUnixEpochToY2K/1000000+microsecSinceY2K/1000000,
 			(microsecFromUnixEpochToY2K%1000000*1000)+(microsecSinceY2K%1000000*1000),
 		).UTC()
+		if plan.location != nil {
+			tim = inLocationWallClock(tim, plan.location)
+		}
 		ts = Timestamp{Time: tim, Valid: true}
 	}
 
 	return scanner.ScanTimestamp(ts)
 }
 
-type scanPlanTextTimestampToTimestampScanner struct{}
+type scanPlanTextTimestampToTimestampScanner struct{ location *time.Location }
 
-func (scanPlanTextTimestampToTimestampScanner) Scan(src []byte, dst any) error {
+func (plan scanPlanTextTimestampToTimestampScanner) Scan(src []byte, dst any) error {
 	scanner := (dst).(TimestampScanner)
 
 	if src == nil {
@@ -302,6 +317,10 @@ func (scanPlanTextTimestampToTimestampScanner) Scan(src []byte, dst any) error {
 			tim = time.Date(year, tim.Month(), tim.Day(), tim.Hour(), tim.Minute(), tim.Second(), tim.Nanosecond(), tim.Location())
 		}
 
+		if plan.location != nil {
+			tim = inLocationWallClock(tim, plan.location)
+		}
+
 		ts = Timestamp{Time: tim, Valid: true}
 	}
 
@@ -2,6 +2,8 @@ package pgtype_test
 
 import (
 	"context"
+	"encoding/binary"
+	"math"
 	"testing"
 	"time"
 
@@ -63,6 +65,31 @@ func TestTimestampCodecDecodeTextInvalid(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestTimestampCodecDecodeTextFractionalSeconds(t *testing.T) {
+	c := &pgtype.TimestampCodec{}
+	var ts pgtype.Timestamp
+	plan := c.PlanScan(nil, pgtype.TimestampOID, pgtype.TextFormatCode, &ts)
+
+	for i, tt := range []struct {
+		src      string
+		expected time.Time
+	}{
+		{"2021-01-01 12:00:00", time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"2021-01-01 12:00:00.1", time.Date(2021, 1, 1, 12, 0, 0, 100000000, time.UTC)},
+		{"2021-01-01 12:00:00.12", time.Date(2021, 1, 1, 12, 0, 0, 120000000, time.UTC)},
+		{"2021-01-01 12:00:00.123", time.Date(2021, 1, 1, 12, 0, 0, 123000000, time.UTC)},
+		{"2021-01-01 12:00:00.1234", time.Date(2021, 1, 1, 12, 0, 0, 123400000, time.UTC)},
+		{"2021-01-01 12:00:00.12345", time.Date(2021, 1, 1, 12, 0, 0, 123450000, time.UTC)},
+		{"2021-01-01 12:00:00.123456", time.Date(2021, 1, 1, 12, 0, 0, 123456000, time.UTC)},
+		{"2021-01-01 12:00:00.000001", time.Date(2021, 1, 1, 12, 0, 0, 1000, time.UTC)},
+	} {
+		err := plan.Scan([]byte(tt.src), &ts)
+		require.NoErrorf(t, err, "%d", i)
+		require.Truef(t, ts.Valid, "%d", i)
+		require.Equalf(t, tt.expected, ts.Time, "%d", i)
+	}
+}
+
 func TestTimestampMarshalJSON(t *testing.T) {
 	successfulTests := []struct {
 		source pgtype.Timestamp
@@ -109,3 +136,74 @@ func TestTimestampUnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestTimestampCodecScanLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	m := pgtype.NewMap()
+	m.RegisterType(&pgtype.Type{Name: "timestamp", OID: pgtype.TimestampOID, Codec: pgtype.TimestampCodec{ScanLocation: loc}})
+
+	for _, tt := range []struct {
+		wallClock time.Time
+		expected  time.Time
+	}{
+		{time.Date(2023, 1, 15, 12, 0, 0, 0, time.UTC), time.Date(2023, 1, 15, 17, 0, 0, 0, time.UTC)},   // EST
+		{time.Date(2023, 3, 12, 1, 59, 59, 0, time.UTC), time.Date(2023, 3, 12, 6, 59, 59, 0, time.UTC)}, // EST, just before the spring forward
+		{time.Date(2023, 3, 12, 3, 0, 0, 0, time.UTC), time.Date(2023, 3, 12, 7, 0, 0, 0, time.UTC)},     // EDT, just after the spring forward
+		{time.Date(2023, 7, 15, 12, 0, 0, 0, time.UTC), time.Date(2023, 7, 15, 16, 0, 0, 0, time.UTC)},   // EDT
+	} {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			buf, err := m.Encode(pgtype.TimestampOID, format, tt.wallClock, nil)
+			require.NoError(t, err)
+
+			var actual time.Time
+			err = m.Scan(pgtype.TimestampOID, format, buf, &actual)
+			require.NoError(t, err)
+			require.Truef(t, tt.expected.Equal(actual), "format %d: expected %v, got %v", format, tt.expected, actual)
+			require.Equal(t, loc, actual.Location())
+		}
+	}
+
+	// Without ScanLocation the wall clock time is returned in UTC.
+	buf, err := m.Encode(pgtype.TimestampOID, pgtype.BinaryFormatCode, time.Date(2023, 7, 15, 12, 0, 0, 0, time.UTC), nil)
+	require.NoError(t, err)
+	var actual time.Time
+	err = pgtype.NewMap().Scan(pgtype.TimestampOID, pgtype.BinaryFormatCode, buf, &actual)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 7, 15, 12, 0, 0, 0, time.UTC), actual)
+}
+
+func TestTimestampCodecBinaryFloatDatetimes(t *testing.T) {
+	for _, tt := range []struct {
+		value        pgtype.Timestamp
+		integerValue int64
+		floatValue   float64
+	}{
+		{pgtype.Timestamp{Time: time.Date(2023, 11, 30, 12, 34, 56, 500000000, time.UTC), Valid: true}, 754662896500000, 754662896.5},
+		{pgtype.Timestamp{Time: time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC), Valid: true}, -1000000, -1},
+		{pgtype.Timestamp{InfinityModifier: pgtype.Infinity, Valid: true}, math.MaxInt64, math.Inf(1)},
+		{pgtype.Timestamp{InfinityModifier: pgtype.NegativeInfinity, Valid: true}, math.MinInt64, math.Inf(-1)},
+	} {
+		for _, floatDatetimes := range []bool{false, true} {
+			m := pgtype.NewMap()
+			m.SetFloatDatetimes(floatDatetimes)
+
+			src := binary.BigEndian.AppendUint64(nil, uint64(tt.integerValue))
+			if floatDatetimes {
+				src = binary.BigEndian.AppendUint64(nil, math.Float64bits(tt.floatValue))
+			}
+
+			var ts pgtype.Timestamp
+			err := m.Scan(pgtype.TimestampOID, pgtype.BinaryFormatCode, src, &ts)
+			require.NoErrorf(t, err, "%v %v", tt.value, floatDatetimes)
+			require.Equalf(t, tt.value, ts, "%v %v", tt.value, floatDatetimes)
+
+			buf, err := m.Encode(pgtype.TimestampOID, pgtype.BinaryFormatCode, tt.value, nil)
+			require.NoErrorf(t, err, "%v %v", tt.value, floatDatetimes)
+			require.Equalf(t, src, buf, "%v %v", tt.value, floatDatetimes)
+		}
+	}
+}
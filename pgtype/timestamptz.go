@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -21,6 +22,43 @@ const (
 	infinityMicrosecondOffset         = 9223372036854775807
 )
 
+// readDatetimeMicroseconds reads an 8 byte binary date and time value as microseconds. Servers built with floating
+// point datetimes send float8 seconds instead of int64 microseconds.
+func readDatetimeMicroseconds(src []byte, floatDatetimes bool) int64 {
+	if !floatDatetimes {
+		return int64(binary.BigEndian.Uint64(src))
+	}
+
+	seconds := math.Float64frombits(binary.BigEndian.Uint64(src))
+	switch {
+	case math.IsInf(seconds, 1):
+		return infinityMicrosecondOffset
+	case math.IsInf(seconds, -1):
+		return negativeInfinityMicrosecondOffset
+	}
+
+	return int64(math.Round(seconds * 1000000))
+}
+
+// appendDatetimeMicroseconds is the inverse of readDatetimeMicroseconds.
+func appendDatetimeMicroseconds(buf []byte, microseconds int64, floatDatetimes bool) []byte {
+	if !floatDatetimes {
+		return pgio.AppendInt64(buf, microseconds)
+	}
+
+	var seconds float64
+	switch microseconds {
+	case infinityMicrosecondOffset:
+		seconds = math.Inf(1)
+	case negativeInfinityMicrosecondOffset:
+		seconds = math.Inf(-1)
+	default:
+		seconds = float64(microseconds) / 1000000
+	}
+
+	return pgio.AppendUint64(buf, math.Float64bits(seconds))
+}
+
 type TimestamptzScanner interface {
 	ScanTimestamptz(v Timestamptz) error
 }
@@ -124,7 +162,12 @@ func (tstz *Timestamptz) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-type TimestamptzCodec struct{}
+type TimestamptzCodec struct {
+	// ScanLocation is the location to return scanned timestamptz values in. This does not change the instant in time that
+	// the timestamptz represents. If nil, values are returned in the local time zone for the binary format and with a
+	// fixed offset matching the server's TimeZone setting for the text format.
+	ScanLocation *time.Location
+}
 
 func (TimestamptzCodec) FormatSupported(format int16) bool {
 	return format == TextFormatCode || format == BinaryFormatCode
@@ -141,7 +184,7 @@ func (TimestamptzCodec) PlanEncode(m *Map, oid uint32, format int16, value any)
 
 	switch format {
 	case BinaryFormatCode:
-		return encodePlanTimestamptzCodecBinary{}
+		return encodePlanTimestamptzCodecBinary{floatDatetimes: m.usesFloatDatetimes()}
 	case TextFormatCode:
 		return encodePlanTimestamptzCodecText{}
 	}
@@ -149,9 +192,9 @@ func (TimestamptzCodec) PlanEncode(m *Map, oid uint32, format int16, value any)
 	return nil
 }
 
-type encodePlanTimestamptzCodecBinary struct{}
+type encodePlanTimestamptzCodecBinary struct{ floatDatetimes bool }
 
-func (encodePlanTimestamptzCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
+func (plan encodePlanTimestamptzCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
 	ts, err := value.(TimestamptzValuer).TimestamptzValue()
 	if err != nil {
 		return nil, err
@@ -172,7 +215,7 @@ func (encodePlanTimestamptzCodecBinary) Encode(value any, buf []byte) (newBuf []
 		microsecSinceY2K = negativeInfinityMicrosecondOffset
 	}
 
-	buf = pgio.AppendInt64(buf, microsecSinceY2K)
+	buf = appendDatetimeMicroseconds(buf, microsecSinceY2K, plan.floatDatetimes)
 
 	return buf, nil
 }
@@ -220,27 +263,30 @@ func (encodePlanTimestamptzCodecText) Encode(value any, buf []byte) (newBuf []by
 	return buf, nil
 }
 
-func (TimestamptzCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+func (c TimestamptzCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
 
 	switch format {
 	case BinaryFormatCode:
 		switch target.(type) {
 		case TimestamptzScanner:
-			return scanPlanBinaryTimestamptzToTimestamptzScanner{}
+			return scanPlanBinaryTimestamptzToTimestamptzScanner{location: c.ScanLocation, floatDatetimes: m.usesFloatDatetimes()}
 		}
 	case TextFormatCode:
 		switch target.(type) {
 		case TimestamptzScanner:
-			return scanPlanTextTimestamptzToTimestamptzScanner{}
+			return scanPlanTextTimestamptzToTimestamptzScanner{location: c.ScanLocation}
 		}
 	}
 
 	return nil
 }
 
-type scanPlanBinaryTimestamptzToTimestamptzScanner struct{}
+type scanPlanBinaryTimestamptzToTimestamptzScanner struct {
+	location       *time.Location
+	floatDatetimes bool
+}
 
-func (scanPlanBinaryTimestamptzToTimestamptzScanner) Scan(src []byte, dst any) error {
+func (plan scanPlanBinaryTimestamptzToTimestamptzScanner) Scan(src []byte, dst any) error {
 	scanner := (dst).(TimestamptzScanner)
 
 	if src == nil {
@@ -252,7 +298,7 @@ func (scanPlanBinaryTimestamptzToTimestamptzScanner) Scan(src []byte, dst any) e
 	}
 
 	var tstz Timestamptz
-	microsecSinceY2K := int64(binary.BigEndian.Uint64(src))
+	microsecSinceY2K := readDatetimeMicroseconds(src, plan.floatDatetimes)
 
 	switch microsecSinceY2K {
 	case infinityMicrosecondOffset:
@@ -264,15 +310,18 @@ func (scanPlanBinaryTimestamptzToTimestamptzScanner) Scan(src []byte, dst any) e
 			microsecFromUnixEpochToY2K/1000000+microsecSinceY2K/1000000,
 			(microsecFromUnixEpochToY2K%1000000*1000)+(microsecSinceY2K%1000000*1000),
 		)
+		if plan.location != nil {
+			tim = tim.In(plan.location)
+		}
 		tstz = Timestamptz{Time: tim, Valid: true}
 	}
 
 	return scanner.ScanTimestamptz(tstz)
 }
 
-type scanPlanTextTimestamptzToTimestamptzScanner struct{}
+type scanPlanTextTimestamptzToTimestamptzScanner struct{ location *time.Location }
 
-func (scanPlanTextTimestamptzToTimestamptzScanner) Scan(src []byte, dst any) error {
+func (plan scanPlanTextTimestamptzToTimestamptzScanner) Scan(src []byte, dst any) error {
 	scanner := (dst).(TimestamptzScanner)
 
 	if src == nil {
@@ -312,6 +361,10 @@ func (scanPlanTextTimestamptzToTimestamptzScanner) Scan(src []byte, dst any) err
 			tim = time.Date(year, tim.Month(), tim.Day(), tim.Hour(), tim.Minute(), tim.Second(), tim.Nanosecond(), tim.Location())
 		}
 
+		if plan.location != nil {
+			tim = tim.In(plan.location)
+		}
+
 		tstz = Timestamptz{Time: tim, Valid: true}
 	}
 
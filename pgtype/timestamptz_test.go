@@ -2,6 +2,8 @@ package pgtype_test
 
 import (
 	"context"
+	"encoding/binary"
+	"math"
 	"testing"
 	"time"
 
@@ -63,6 +65,36 @@ func TestTimestamptzDecodeTextInvalid(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestTimestamptzDecodeTextFractionalSecondsAndOffsets(t *testing.T) {
+	c := &pgtype.TimestamptzCodec{}
+	var tstz pgtype.Timestamptz
+	plan := c.PlanScan(nil, pgtype.TimestamptzOID, pgtype.TextFormatCode, &tstz)
+
+	for i, tt := range []struct {
+		src      string
+		expected time.Time
+	}{
+		{"2021-01-01 12:00:00+00", time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"2021-01-01 12:00:00.1+00", time.Date(2021, 1, 1, 12, 0, 0, 100000000, time.UTC)},
+		{"2021-01-01 12:00:00.12+00", time.Date(2021, 1, 1, 12, 0, 0, 120000000, time.UTC)},
+		{"2021-01-01 12:00:00.123+00", time.Date(2021, 1, 1, 12, 0, 0, 123000000, time.UTC)},
+		{"2021-01-01 12:00:00.1234+00", time.Date(2021, 1, 1, 12, 0, 0, 123400000, time.UTC)},
+		{"2021-01-01 12:00:00.12345+00", time.Date(2021, 1, 1, 12, 0, 0, 123450000, time.UTC)},
+		{"2021-01-01 12:00:00.123456+00", time.Date(2021, 1, 1, 12, 0, 0, 123456000, time.UTC)},
+		{"2021-01-01 12:00:00.000001+00", time.Date(2021, 1, 1, 12, 0, 0, 1000, time.UTC)},
+		{"2021-01-01 12:00:00.123456+02", time.Date(2021, 1, 1, 10, 0, 0, 123456000, time.UTC)},
+		{"2021-01-01 12:00:00.123456-05:30", time.Date(2021, 1, 1, 17, 30, 0, 123456000, time.UTC)},
+		{"2021-01-01 12:00:00.5+05:30:15", time.Date(2021, 1, 1, 6, 29, 45, 500000000, time.UTC)},
+		{"2021-01-01 12:00:00Z", time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"2021-01-01 12:00:00.123456Z", time.Date(2021, 1, 1, 12, 0, 0, 123456000, time.UTC)},
+	} {
+		err := plan.Scan([]byte(tt.src), &tstz)
+		require.NoErrorf(t, err, "%d", i)
+		require.Truef(t, tstz.Valid, "%d", i)
+		require.Truef(t, tt.expected.Equal(tstz.Time), "%d: expected %v, got %v", i, tt.expected, tstz.Time)
+	}
+}
+
 func TestTimestamptzMarshalJSON(t *testing.T) {
 	successfulTests := []struct {
 		source pgtype.Timestamptz
@@ -109,3 +141,68 @@ func TestTimestamptzUnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestTimestamptzCodecScanLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	m := pgtype.NewMap()
+	m.RegisterType(&pgtype.Type{Name: "timestamptz", OID: pgtype.TimestamptzOID, Codec: pgtype.TimestamptzCodec{ScanLocation: loc}})
+
+	for _, tt := range []struct {
+		instant  time.Time
+		wallHour int
+	}{
+		{time.Date(2023, 3, 12, 6, 59, 59, 0, time.UTC), 1}, // EST, just before the spring forward
+		{time.Date(2023, 3, 12, 7, 0, 0, 0, time.UTC), 3},   // EDT, just after the spring forward
+		{time.Date(2023, 11, 5, 5, 30, 0, 0, time.UTC), 1},  // EDT, first 01:30
+		{time.Date(2023, 11, 5, 6, 30, 0, 0, time.UTC), 1},  // EST, second 01:30
+	} {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			buf, err := m.Encode(pgtype.TimestamptzOID, format, tt.instant, nil)
+			require.NoError(t, err)
+
+			var actual time.Time
+			err = m.Scan(pgtype.TimestamptzOID, format, buf, &actual)
+			require.NoError(t, err)
+			require.Truef(t, tt.instant.Equal(actual), "format %d: expected %v, got %v", format, tt.instant, actual)
+			require.Equal(t, loc, actual.Location())
+			require.Equal(t, tt.wallHour, actual.Hour())
+		}
+	}
+}
+
+func TestTimestamptzCodecBinaryFloatDatetimes(t *testing.T) {
+	for _, tt := range []struct {
+		value        pgtype.Timestamptz
+		integerValue int64
+		floatValue   float64
+	}{
+		{pgtype.Timestamptz{Time: time.Date(2023, 11, 30, 12, 34, 56, 500000000, time.UTC), Valid: true}, 754662896500000, 754662896.5},
+		{pgtype.Timestamptz{Time: time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC), Valid: true}, -1000000, -1},
+		{pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}, math.MaxInt64, math.Inf(1)},
+		{pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true}, math.MinInt64, math.Inf(-1)},
+	} {
+		for _, floatDatetimes := range []bool{false, true} {
+			m := pgtype.NewMap()
+			m.SetFloatDatetimes(floatDatetimes)
+
+			src := binary.BigEndian.AppendUint64(nil, uint64(tt.integerValue))
+			if floatDatetimes {
+				src = binary.BigEndian.AppendUint64(nil, math.Float64bits(tt.floatValue))
+			}
+
+			var tstz pgtype.Timestamptz
+			err := m.Scan(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, src, &tstz)
+			require.NoErrorf(t, err, "%v %v", tt.value, floatDatetimes)
+			require.Equalf(t, tt.value.InfinityModifier, tstz.InfinityModifier, "%v %v", tt.value, floatDatetimes)
+			require.Truef(t, tt.value.Time.Equal(tstz.Time), "%v %v: got %v", tt.value, floatDatetimes, tstz.Time)
+
+			buf, err := m.Encode(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, tt.value, nil)
+			require.NoErrorf(t, err, "%v %v", tt.value, floatDatetimes)
+			require.Equalf(t, src, buf, "%v %v", tt.value, floatDatetimes)
+		}
+	}
+}
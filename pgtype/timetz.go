@@ -0,0 +1,256 @@
+package pgtype
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/internal/pgio"
+)
+
+type TimetzScanner interface {
+	ScanTimetz(v Timetz) error
+}
+
+type TimetzValuer interface {
+	TimetzValue() (Timetz, error)
+}
+
+// Timetz represents the PostgreSQL timetz type. The PostgreSQL timetz is a time of day with a fixed UTC offset.
+//
+// Like Time, the time of day is represented as the number of microseconds since midnight. OffsetSeconds is the offset
+// from UTC in seconds east of UTC. e.g. 04:05:06+02 has an OffsetSeconds of 7200.
+type Timetz struct {
+	Microseconds  int64 // Number of microseconds since midnight
+	OffsetSeconds int32 // Offset from UTC in seconds east of UTC
+	Valid         bool
+}
+
+func (t *Timetz) ScanTimetz(v Timetz) error {
+	*t = v
+	return nil
+}
+
+func (t Timetz) TimetzValue() (Timetz, error) {
+	return t, nil
+}
+
+// TimeOn returns t as a time.Time on the year, month, and day of date in a fixed zone with t's offset.
+func (t Timetz) TimeOn(date time.Time) time.Time {
+	year, month, day := date.Date()
+	loc := time.FixedZone("", int(t.OffsetSeconds))
+	return time.Date(year, month, day, 0, 0, 0, 0, loc).Add(time.Duration(t.Microseconds) * time.Microsecond)
+}
+
+// Scan implements the database/sql Scanner interface.
+func (t *Timetz) Scan(src any) error {
+	if src == nil {
+		*t = Timetz{}
+		return nil
+	}
+
+	switch src := src.(type) {
+	case string:
+		return scanPlanTextAnyToTimetzScanner{}.Scan([]byte(src), t)
+	}
+
+	return fmt.Errorf("cannot scan %T", src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+func (t Timetz) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+
+	buf, err := TimetzCodec{}.PlanEncode(nil, 0, TextFormatCode, t).Encode(t, nil)
+	if err != nil {
+		return nil, err
+	}
+	return string(buf), err
+}
+
+type TimetzCodec struct{}
+
+func (TimetzCodec) FormatSupported(format int16) bool {
+	return format == TextFormatCode || format == BinaryFormatCode
+}
+
+func (TimetzCodec) PreferredFormat() int16 {
+	return BinaryFormatCode
+}
+
+func (TimetzCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	if _, ok := value.(TimetzValuer); !ok {
+		return nil
+	}
+
+	switch format {
+	case BinaryFormatCode:
+		return encodePlanTimetzCodecBinary{}
+	case TextFormatCode:
+		return encodePlanTimetzCodecText{}
+	}
+
+	return nil
+}
+
+type encodePlanTimetzCodecBinary struct{}
+
+func (encodePlanTimetzCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	t, err := value.(TimetzValuer).TimetzValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.Valid {
+		return nil, nil
+	}
+
+	buf = pgio.AppendInt64(buf, t.Microseconds)
+	// PostgreSQL stores the zone as seconds west of UTC.
+	buf = pgio.AppendInt32(buf, -t.OffsetSeconds)
+	return buf, nil
+}
+
+type encodePlanTimetzCodecText struct{}
+
+func (encodePlanTimetzCodecText) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	t, err := value.(TimetzValuer).TimetzValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.Valid {
+		return nil, nil
+	}
+
+	buf, err = encodePlanTimeCodecText{}.Encode(Time{Microseconds: t.Microseconds, Valid: true}, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := t.OffsetSeconds
+	if offset < 0 {
+		buf = append(buf, '-')
+		offset = -offset
+	} else {
+		buf = append(buf, '+')
+	}
+
+	hours := offset / 3600
+	minutes := offset % 3600 / 60
+	seconds := offset % 60
+
+	buf = append(buf, fmt.Sprintf("%02d:%02d", hours, minutes)...)
+	if seconds != 0 {
+		buf = append(buf, fmt.Sprintf(":%02d", seconds)...)
+	}
+
+	return buf, nil
+}
+
+func (TimetzCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+
+	switch format {
+	case BinaryFormatCode:
+		switch target.(type) {
+		case TimetzScanner:
+			return scanPlanBinaryTimetzToTimetzScanner{}
+		}
+	case TextFormatCode:
+		switch target.(type) {
+		case TimetzScanner:
+			return scanPlanTextAnyToTimetzScanner{}
+		}
+	}
+
+	return nil
+}
+
+type scanPlanBinaryTimetzToTimetzScanner struct{}
+
+func (scanPlanBinaryTimetzToTimetzScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(TimetzScanner)
+
+	if src == nil {
+		return scanner.ScanTimetz(Timetz{})
+	}
+
+	if len(src) != 12 {
+		return fmt.Errorf("invalid length for timetz: %v", len(src))
+	}
+
+	usec := int64(binary.BigEndian.Uint64(src))
+	zone := int32(binary.BigEndian.Uint32(src[8:]))
+
+	return scanner.ScanTimetz(Timetz{Microseconds: usec, OffsetSeconds: -zone, Valid: true})
+}
+
+type scanPlanTextAnyToTimetzScanner struct{}
+
+func (scanPlanTextAnyToTimetzScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(TimetzScanner)
+
+	if src == nil {
+		return scanner.ScanTimetz(Timetz{})
+	}
+
+	s := string(src)
+
+	signIdx := strings.LastIndexAny(s, "+-")
+	if signIdx < 8 {
+		return fmt.Errorf("cannot decode %v into Timetz", s)
+	}
+
+	var t Time
+	err := scanPlanTextAnyToTimeScanner{}.Scan([]byte(s[:signIdx]), &t)
+	if err != nil {
+		return fmt.Errorf("cannot decode %v into Timetz", s)
+	}
+
+	var offset int32
+	for i, part := range strings.Split(s[signIdx+1:], ":") {
+		if i > 2 || len(part) != 2 {
+			return fmt.Errorf("cannot decode %v into Timetz", s)
+		}
+		n, err := strconv.ParseInt(part, 10, 32)
+		if err != nil {
+			return fmt.Errorf("cannot decode %v into Timetz", s)
+		}
+		switch i {
+		case 0:
+			offset += int32(n) * 3600
+		case 1:
+			offset += int32(n) * 60
+		case 2:
+			offset += int32(n)
+		}
+	}
+
+	if s[signIdx] == '-' {
+		offset = -offset
+	}
+
+	return scanner.ScanTimetz(Timetz{Microseconds: t.Microseconds, OffsetSeconds: offset, Valid: true})
+}
+
+func (c TimetzCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	return codecDecodeToTextFormat(c, m, oid, format, src)
+}
+
+func (c TimetzCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var t Timetz
+	err := codecScan(c, m, oid, format, src, &t)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,77 @@
+package pgtype_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimetzCodec(t *testing.T) {
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "timetz", []pgxtest.ValueRoundTripTest{
+		{
+			pgtype.Timetz{Microseconds: 0, OffsetSeconds: 0, Valid: true},
+			new(pgtype.Timetz),
+			isExpectedEq(pgtype.Timetz{Microseconds: 0, OffsetSeconds: 0, Valid: true}),
+		},
+		{
+			pgtype.Timetz{Microseconds: 14706000000, OffsetSeconds: 7200, Valid: true},
+			new(pgtype.Timetz),
+			isExpectedEq(pgtype.Timetz{Microseconds: 14706000000, OffsetSeconds: 7200, Valid: true}),
+		},
+		{
+			pgtype.Timetz{Microseconds: 14706123456, OffsetSeconds: -(7*3600 + 30*60), Valid: true},
+			new(pgtype.Timetz),
+			isExpectedEq(pgtype.Timetz{Microseconds: 14706123456, OffsetSeconds: -(7*3600 + 30*60), Valid: true}),
+		},
+		{
+			pgtype.Timetz{Microseconds: 86400000000, OffsetSeconds: 5*3600 + 30*60 + 15, Valid: true},
+			new(pgtype.Timetz),
+			isExpectedEq(pgtype.Timetz{Microseconds: 86400000000, OffsetSeconds: 5*3600 + 30*60 + 15, Valid: true}),
+		},
+		{pgtype.Timetz{}, new(pgtype.Timetz), isExpectedEq(pgtype.Timetz{})},
+		{nil, new(pgtype.Timetz), isExpectedEq(pgtype.Timetz{})},
+	})
+}
+
+func TestTimetzScanText(t *testing.T) {
+	for i, tt := range []struct {
+		src      string
+		expected pgtype.Timetz
+	}{
+		{"04:05:06+02", pgtype.Timetz{Microseconds: 14706000000, OffsetSeconds: 7200, Valid: true}},
+		{"04:05:06.5-07:30", pgtype.Timetz{Microseconds: 14706500000, OffsetSeconds: -(7*3600 + 30*60), Valid: true}},
+		{"04:05:06.000001+05:30:15", pgtype.Timetz{Microseconds: 14706000001, OffsetSeconds: 5*3600 + 30*60 + 15, Valid: true}},
+		{"00:00:00+00", pgtype.Timetz{Microseconds: 0, OffsetSeconds: 0, Valid: true}},
+	} {
+		var timetz pgtype.Timetz
+		err := timetz.Scan(tt.src)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, timetz, "%d", i)
+	}
+
+	for i, src := range []string{"04:05:06", "04:05:06+2", "04:05:06+ab", "04:05+02"} {
+		var timetz pgtype.Timetz
+		err := timetz.Scan(src)
+		require.Errorf(t, err, "%d", i)
+	}
+}
+
+func TestTimetzValue(t *testing.T) {
+	v, err := pgtype.Timetz{Microseconds: 14706000000, OffsetSeconds: -(7*3600 + 30*60), Valid: true}.Value()
+	require.NoError(t, err)
+	require.Equal(t, "04:05:06.000000-07:30", v)
+}
+
+func TestTimetzTimeOn(t *testing.T) {
+	timetz := pgtype.Timetz{Microseconds: 14706000000, OffsetSeconds: 7200, Valid: true}
+	tim := timetz.TimeOn(time.Date(2023, 5, 17, 23, 59, 0, 0, time.UTC))
+
+	require.True(t, tim.Equal(time.Date(2023, 5, 17, 2, 5, 6, 0, time.UTC)))
+	_, offset := tim.Zone()
+	require.Equal(t, 7200, offset)
+}
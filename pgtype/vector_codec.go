@@ -0,0 +1,77 @@
+package pgtype
+
+import (
+	"database/sql/driver"
+	"strings"
+)
+
+// VectorCodec is a codec for the int2vector and oidvector catalog types. These are one-dimensional arrays whose text
+// format is the elements separated by spaces instead of the usual array literal. Their binary format is the same as an
+// array. Values are decoded into the same Go types as ArrayCodec such as []int16 for int2vector and []uint32 for
+// oidvector.
+//
+// VectorCodec prefers the text format so that, as before these types had a codec, they can be scanned into a string
+// and are returned as their text representation through database/sql. The binary format is supported when requested
+// explicitly.
+//
+// VectorCodec only supports decoding. These types are used in the system catalogs and are not intended to be written by
+// clients.
+type VectorCodec struct {
+	ElementType *Type
+}
+
+func (c *VectorCodec) arrayCodec() *ArrayCodec {
+	return &ArrayCodec{ElementType: c.ElementType}
+}
+
+func (c *VectorCodec) FormatSupported(format int16) bool {
+	return c.ElementType.Codec.FormatSupported(format)
+}
+
+func (c *VectorCodec) PreferredFormat() int16 {
+	return TextFormatCode
+}
+
+func (c *VectorCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	return nil
+}
+
+func (c *VectorCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	plan := c.arrayCodec().PlanScan(m, oid, format, target)
+	if plan == nil {
+		return nil
+	}
+
+	if format == TextFormatCode {
+		return &scanPlanVectorCodecText{next: plan}
+	}
+
+	return plan
+}
+
+func (c *VectorCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	return c.arrayCodec().DecodeDatabaseSQLValue(m, oid, format, src)
+}
+
+func (c *VectorCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var slice []any
+	err := m.PlanScan(oid, format, &slice).Scan(src, &slice)
+	return slice, err
+}
+
+// scanPlanVectorCodecText converts the space separated text format to an array literal for the array scan plan.
+type scanPlanVectorCodecText struct {
+	next ScanPlan
+}
+
+func (plan *scanPlanVectorCodecText) Scan(src []byte, dst any) error {
+	if src == nil {
+		return plan.next.Scan(nil, dst)
+	}
+
+	return plan.next.Scan([]byte("{"+strings.Join(strings.Fields(string(src)), ",")+"}"), dst)
+}
@@ -0,0 +1,117 @@
+package pgtype_test
+
+import (
+	"context"
+	"testing"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectorCodecScanText(t *testing.T) {
+	m := pgtype.NewMap()
+
+	var int2s []int16
+	err := m.Scan(pgtype.Int2VectorOID, pgtype.TextFormatCode, []byte("1 -2 3"), &int2s)
+	require.NoError(t, err)
+	require.Equal(t, []int16{1, -2, 3}, int2s)
+
+	var oids []uint32
+	err = m.Scan(pgtype.OIDVectorOID, pgtype.TextFormatCode, []byte("23 4294967295"), &oids)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{23, 4294967295}, oids)
+
+	err = m.Scan(pgtype.OIDVectorOID, pgtype.TextFormatCode, []byte(""), &oids)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{}, oids)
+
+	err = m.Scan(pgtype.OIDVectorOID, pgtype.TextFormatCode, nil, &oids)
+	require.NoError(t, err)
+	require.Nil(t, oids)
+}
+
+func TestVectorCodecScanBinary(t *testing.T) {
+	m := pgtype.NewMap()
+
+	src := []byte{
+		0, 0, 0, 1, // dimensions
+		0, 0, 0, 0, // contains null
+		0, 0, 0, 21, // element OID
+		0, 0, 0, 2, 0, 0, 0, 0, // length and lower bound
+		0, 0, 0, 2, 0, 1, // element 1
+		0, 0, 0, 2, 0, 3, // element 2
+	}
+
+	var int2s []int16
+	err := m.Scan(pgtype.Int2VectorOID, pgtype.BinaryFormatCode, src, &int2s)
+	require.NoError(t, err)
+	require.Equal(t, []int16{1, 3}, int2s)
+}
+
+func TestVectorCodecPreferredFormat(t *testing.T) {
+	m := pgtype.NewMap()
+	require.Equal(t, int16(pgtype.TextFormatCode), m.FormatCodeForOID(pgtype.Int2VectorOID))
+	require.Equal(t, int16(pgtype.TextFormatCode), m.FormatCodeForOID(pgtype.OIDVectorOID))
+
+	var s string
+	err := m.Scan(pgtype.Int2VectorOID, pgtype.TextFormatCode, []byte("3 1"), &s)
+	require.NoError(t, err)
+	require.Equal(t, "3 1", s)
+
+	int2VectorType, ok := m.TypeForOID(pgtype.Int2VectorOID)
+	require.True(t, ok)
+	v, err := int2VectorType.Codec.DecodeDatabaseSQLValue(m, pgtype.Int2VectorOID, pgtype.TextFormatCode, []byte("3 1"))
+	require.NoError(t, err)
+	require.Equal(t, "3 1", v)
+}
+
+func TestVectorCodecDecodeValue(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		for _, mode := range []pgx.QueryExecMode{pgx.QueryExecModeSimpleProtocol, pgx.QueryExecModeDescribeExec} {
+			var int2s []int16
+			var oids []uint32
+			err := conn.QueryRow(ctx, "select '1 2 3'::int2vector, '23 25'::oidvector", mode).Scan(&int2s, &oids)
+			require.NoErrorf(t, err, "%v", mode)
+			assert.Equalf(t, []int16{1, 2, 3}, int2s, "%v", mode)
+			assert.Equalf(t, []uint32{23, 25}, oids, "%v", mode)
+
+			var value any
+			err = conn.QueryRow(ctx, "select '1 2 3'::int2vector", mode).Scan(&value)
+			require.NoErrorf(t, err, "%v", mode)
+			assert.Equalf(t, []any{int16(1), int16(2), int16(3)}, value, "%v", mode)
+		}
+	})
+}
+
+func TestVectorCodecScanIndexKey(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		_, err := conn.Exec(ctx, `create temporary table vector_codec_test (a int, b int, c int);
+create index on vector_codec_test (c, a);`)
+		require.NoError(t, err)
+
+		for _, mode := range []pgx.QueryExecMode{pgx.QueryExecModeSimpleProtocol, pgx.QueryExecModeDescribeExec} {
+			var indkey []int16
+			var indclass []uint32
+			err := conn.QueryRow(ctx,
+				"select indkey, indclass from pg_index where indrelid = 'vector_codec_test'::regclass",
+				mode,
+			).Scan(&indkey, &indclass)
+			require.NoErrorf(t, err, "%v", mode)
+			assert.Equalf(t, []int16{3, 1}, indkey, "%v", mode)
+			assert.Lenf(t, indclass, 2, "%v", mode)
+		}
+
+		for _, mode := range pgxtest.AllQueryExecModes {
+			var indkey string
+			err := conn.QueryRow(ctx,
+				"select indkey from pg_index where indrelid = 'vector_codec_test'::regclass",
+				mode,
+			).Scan(&indkey)
+			require.NoErrorf(t, err, "%v", mode)
+			assert.Equalf(t, "3 1", indkey, "%v", mode)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package pgtype
+
+import (
+	"database/sql/driver"
+)
+
+// VoidCodec is for the PostgreSQL void pseudo-type. It is the result type of functions that do not return a value.
+// void has no value so it decodes to nil, or an empty string when scanned into a *string. It cannot be encoded.
+type VoidCodec struct{}
+
+func (VoidCodec) FormatSupported(format int16) bool {
+	return format == TextFormatCode || format == BinaryFormatCode
+}
+
+func (VoidCodec) PreferredFormat() int16 {
+	return BinaryFormatCode
+}
+
+func (VoidCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	return nil
+}
+
+func (VoidCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	switch format {
+	case TextFormatCode, BinaryFormatCode:
+		switch target.(type) {
+		case *string:
+			return scanPlanVoidToString{}
+		}
+	}
+
+	return nil
+}
+
+type scanPlanVoidToString struct{}
+
+func (scanPlanVoidToString) Scan(src []byte, dst any) error {
+	*(dst.(*string)) = ""
+	return nil
+}
+
+func (c VoidCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	return nil, nil
+}
+
+func (c VoidCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	return nil, nil
+}
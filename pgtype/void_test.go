@@ -0,0 +1,41 @@
+package pgtype_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoidCodecScan(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var s string
+		err := conn.QueryRow(ctx, "select pg_sleep(0)").Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "", s)
+
+		var v any
+		err = conn.QueryRow(ctx, "select pg_sleep(0)").Scan(&v)
+		require.NoError(t, err)
+		require.Nil(t, v)
+
+		rows, err := conn.Query(ctx, "select pg_sleep(0)")
+		require.NoError(t, err)
+		values, err := pgx.CollectOneRow(rows, func(row pgx.CollectableRow) ([]any, error) { return row.Values() })
+		require.NoError(t, err)
+		require.Equal(t, []any{nil}, values)
+	})
+}
+
+func TestVoidCodecDecodeValue(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		var v any = "not nil"
+		err := m.Scan(pgtype.VoidOID, format, []byte{}, &v)
+		require.NoError(t, err)
+		require.Nil(t, v)
+	}
+}
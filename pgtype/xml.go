@@ -0,0 +1,83 @@
+package pgtype
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// XMLCodec is the codec for the PostgreSQL xml type. PostgreSQL sends and receives xml values as XML text in both the
+// text and binary formats, so XMLCodec scans into and encodes from the same Go types as TextCodec (string, []byte,
+// TextScanner, and TextValuer).
+//
+// The server checks that values are well-formed. If ValidateOnEncode is true values are also checked with
+// encoding/xml before they are sent so an error is returned without a round trip to the server. Both documents and
+// content fragments with multiple top-level nodes are accepted. Validation requires the parameter type to be known, so
+// it is not performed with QueryExecModeExec or QueryExecModeSimpleProtocol.
+type XMLCodec struct {
+	TextCodec
+	ValidateOnEncode bool
+}
+
+func (c XMLCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	plan := c.TextCodec.PlanEncode(m, oid, format, value)
+	if plan == nil || !c.ValidateOnEncode {
+		return plan
+	}
+
+	return &encodePlanXMLCodecValidate{next: plan}
+}
+
+func (c XMLCodec) wrapTextEncodePlan(plan EncodePlan) EncodePlan {
+	if !c.ValidateOnEncode {
+		return plan
+	}
+
+	return &encodePlanXMLCodecValidate{next: plan}
+}
+
+type encodePlanXMLCodecValidate struct {
+	next EncodePlan
+}
+
+func (plan *encodePlanXMLCodecValidate) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	start := len(buf)
+	newBuf, err = plan.next.Encode(value, buf)
+	if err != nil || newBuf == nil {
+		return newBuf, err
+	}
+
+	err = validateXML(newBuf[start:])
+	if err != nil {
+		return nil, err
+	}
+
+	return newBuf, nil
+}
+
+// validateXML returns an error if src is not well-formed XML.
+func validateXML(src []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(src))
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			if depth != 0 {
+				return errors.New("invalid xml: unclosed element")
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid xml: %w", err)
+		}
+
+		switch token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
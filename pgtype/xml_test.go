@@ -0,0 +1,97 @@
+package pgtype_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXMLCodec(t *testing.T) {
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "xml", []pgxtest.ValueRoundTripTest{
+		{"", new(string), isExpectedEq("")},
+		{"<doc/>", new(string), isExpectedEq("<doc/>")},
+		{`<doc attr="1"><item>café €</item></doc>`, new(string), isExpectedEq(`<doc attr="1"><item>café €</item></doc>`)},
+		{"text <a>and</a> <b/> fragments", new(string), isExpectedEq("text <a>and</a> <b/> fragments")},
+		{pgtype.Text{String: "<doc/>", Valid: true}, new(pgtype.Text), isExpectedEq(pgtype.Text{String: "<doc/>", Valid: true})},
+		{pgtype.Text{}, new(pgtype.Text), isExpectedEq(pgtype.Text{})},
+		{nil, new(*string), isExpectedEq((*string)(nil))},
+	})
+}
+
+func TestXMLCodecValidateOnEncode(t *testing.T) {
+	m := pgtype.NewMap()
+
+	// The server validates xml so invalid values are only rejected by the client when requested.
+	_, err := m.Encode(pgtype.XMLOID, pgtype.BinaryFormatCode, "<doc>", nil)
+	require.NoError(t, err)
+
+	m.RegisterType(&pgtype.Type{Name: "xml", OID: pgtype.XMLOID, Codec: pgtype.XMLCodec{ValidateOnEncode: true}})
+
+	buf, err := m.Encode(pgtype.XMLOID, pgtype.BinaryFormatCode, "<doc><item/></doc> trailing <b/>", nil)
+	require.NoError(t, err)
+	require.Equal(t, "<doc><item/></doc> trailing <b/>", string(buf))
+
+	buf, err = m.Encode(pgtype.XMLOID, pgtype.BinaryFormatCode, []byte("<doc/>"), []byte("prefix"))
+	require.NoError(t, err)
+	require.Equal(t, "prefix<doc/>", string(buf))
+
+	for _, invalid := range []string{"<doc>", "<doc></item>", "<doc attr=1/>", "&undefined;"} {
+		_, err = m.Encode(pgtype.XMLOID, pgtype.BinaryFormatCode, invalid, nil)
+		require.Errorf(t, err, "%s", invalid)
+
+		_, err = m.Encode(pgtype.XMLOID, pgtype.TextFormatCode, invalid, nil)
+		require.Errorf(t, err, "%s", invalid)
+
+		_, err = m.Encode(pgtype.XMLOID, pgtype.TextFormatCode, pgtype.Text{String: invalid, Valid: true}, nil)
+		require.Errorf(t, err, "%s", invalid)
+	}
+
+	buf, err = m.Encode(pgtype.XMLOID, pgtype.TextFormatCode, "<doc/>", nil)
+	require.NoError(t, err)
+	require.Equal(t, "<doc/>", string(buf))
+
+	buf, err = m.Encode(pgtype.XMLOID, pgtype.BinaryFormatCode, pgtype.Text{}, nil)
+	require.NoError(t, err)
+	require.Nil(t, buf)
+
+	// A pointer to the codec validates the same way.
+	m = pgtype.NewMap()
+	m.RegisterType(&pgtype.Type{Name: "xml", OID: pgtype.XMLOID, Codec: &pgtype.XMLCodec{ValidateOnEncode: true}})
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		_, err = m.Encode(pgtype.XMLOID, format, "<doc>", nil)
+		require.Errorf(t, err, "%d", format)
+
+		buf, err = m.Encode(pgtype.XMLOID, format, "<doc/>", nil)
+		require.NoErrorf(t, err, "%d", format)
+		require.Equalf(t, "<doc/>", string(buf), "%d", format)
+	}
+}
+
+func TestXMLCodecValidateOnEncodeQueryArgument(t *testing.T) {
+	pgxtest.RunWithQueryExecModes(context.Background(), t, defaultConnTestRunner, pgxtest.KnownOIDQueryExecModes, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does not support xml")
+
+		conn.TypeMap().RegisterType(&pgtype.Type{Name: "xml", OID: pgtype.XMLOID, Codec: pgtype.XMLCodec{ValidateOnEncode: true}})
+
+		for _, arg := range []any{"<doc>", pgtype.Text{String: "<doc>", Valid: true}} {
+			var s string
+			err := conn.QueryRow(ctx, "select $1::xml", arg).Scan(&s)
+			require.ErrorContainsf(t, err, "invalid xml", "%#v", arg)
+
+			var pgErr *pgconn.PgError
+			require.Falsef(t, errors.As(err, &pgErr), "%#v", arg)
+		}
+
+		var s string
+		err := conn.QueryRow(ctx, "select $1::xml", "<doc/>").Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "<doc/>", s)
+	})
+}
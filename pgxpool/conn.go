@@ -3,6 +3,7 @@ package pgxpool
 import (
 	"context"
 	"sync/atomic"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -46,13 +47,28 @@ func (c *Conn) Release() {
 		return
 	}
 
-	if c.p.afterRelease == nil {
+	cr := res.Value()
+	if !cr.resetRole && c.p.afterRelease == nil {
 		res.Release()
 		return
 	}
 
 	go func() {
-		if c.p.afterRelease(conn) {
+		if cr.resetRole {
+			cr.resetRole = false
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			_, err := conn.Exec(ctx, "reset role")
+			cancel()
+			if err != nil {
+				res.Destroy()
+				// Signal to the health check to run since we just destroyed a connections
+				// and we might be below minConns now
+				c.p.triggerHealthCheck()
+				return
+			}
+		}
+
+		if c.p.afterRelease == nil || c.p.afterRelease(conn) {
 			res.Release()
 		} else {
 			res.Destroy()
@@ -74,6 +90,7 @@ func (c *Conn) Hijack() *pgx.Conn {
 	res := c.res
 	c.res = nil
 
+	c.p.untrackConn(res.Value())
 	res.Hijack()
 
 	return conn
@@ -23,5 +23,14 @@ specified here. In addition, a config struct can be created by [ParseConfig].
 
 A pool returns without waiting for any connections to be established. Acquire a connection immediately after creating
 the pool to check if a connection can successfully be established.
+
+Primary and Replica Servers
+
+[ReadWritePool] combines a writer pool for a primary server with reader pools for replicas:
+
+    rwp := pgxpool.NewReadWritePool(primaryPool, replicaPool1, replicaPool2)
+
+    conn, err := rwp.AcquireRead(ctx) // round-robins across replicas, skipping any that cannot be acquired from
+    conn, err := rwp.AcquireWrite(ctx) // always uses the primary
 */
 package pgxpool
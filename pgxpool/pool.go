@@ -2,6 +2,7 @@ package pgxpool
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"runtime"
@@ -20,6 +21,11 @@ var defaultMinConns = int32(0)
 var defaultMaxConnLifetime = time.Hour
 var defaultMaxConnIdleTime = time.Minute * 30
 var defaultHealthCheckPeriod = time.Minute
+var defaultConnectRetryBackoff = 100 * time.Millisecond
+var defaultConnectRetryMaxBackoff = 5 * time.Second
+
+// ErrPoolClosed is returned when attempting to acquire a connection from a pool that has been closed or is draining.
+var ErrPoolClosed = puddle.ErrClosedPool
 
 type connResource struct {
 	conn       *pgx.Conn
@@ -27,6 +33,7 @@ type connResource struct {
 	poolRows   []poolRow
 	poolRowss  []poolRows
 	maxAgeTime time.Time
+	resetRole  bool // set by AcquireAs so Release runs RESET ROLE
 }
 
 func (cr *connResource) getConn(p *Pool, res *puddle.Resource[*connResource]) *Conn {
@@ -79,22 +86,32 @@ type Pool struct {
 	lifetimeDestroyCount int64
 	idleDestroyCount     int64
 
-	p                     *puddle.Pool[*connResource]
-	config                *Config
-	beforeConnect         func(context.Context, *pgx.ConnConfig) error
-	afterConnect          func(context.Context, *pgx.Conn) error
-	beforeAcquire         func(context.Context, *pgx.Conn) bool
-	afterRelease          func(*pgx.Conn) bool
-	beforeClose           func(*pgx.Conn)
-	minConns              int32
-	maxConns              int32
-	maxConnLifetime       time.Duration
-	maxConnLifetimeJitter time.Duration
-	maxConnIdleTime       time.Duration
-	healthCheckPeriod     time.Duration
+	p                      *puddle.Pool[*connResource]
+	config                 *Config
+	beforeConnect          func(context.Context, *pgx.ConnConfig) error
+	afterConnect           func(context.Context, *pgx.Conn) error
+	beforeAcquire          func(context.Context, *pgx.Conn) bool
+	afterRelease           func(*pgx.Conn) bool
+	beforeClose            func(*pgx.Conn)
+	minConns               int32
+	maxConns               int32
+	maxConnLifetime        time.Duration
+	maxConnLifetimeJitter  time.Duration
+	maxConnIdleTime        time.Duration
+	healthCheckPeriod      time.Duration
+	connectRetries         int
+	connectRetryBackoff    time.Duration
+	connectRetryMaxBackoff time.Duration
 
 	healthCheckChan chan struct{}
 
+	draining atomic.Bool
+
+	// allConns contains every connection created by the pool that has not been destroyed or hijacked. It is used by
+	// CloseForce to find connections that are still acquired.
+	allConnsMux sync.Mutex
+	allConns    map[*connResource]struct{}
+
 	closeOnce sync.Once
 	closeChan chan struct{}
 }
@@ -105,7 +122,9 @@ type Config struct {
 	ConnConfig *pgx.ConnConfig
 
 	// BeforeConnect is called before a new connection is made. It is passed a copy of the underlying pgx.ConnConfig and
-	// will not impact any existing open connections.
+	// will not impact any existing open connections. It is called for every new connection so it can be used to supply
+	// short-lived credentials such as IAM auth tokens by setting cfg.Password. If it returns an error the connection is
+	// not made and the error is returned from Acquire.
 	BeforeConnect func(context.Context, *pgx.ConnConfig) error
 
 	// AfterConnect is called after a connection is established, but before it is added to the pool.
@@ -120,7 +139,9 @@ type Config struct {
 	// return the connection to the pool or false to destroy the connection.
 	AfterRelease func(*pgx.Conn) bool
 
-	// BeforeClose is called right before a connection is closed and removed from the pool.
+	// BeforeClose is called right before a connection is closed and removed from the pool. It is called regardless of
+	// why the connection is being closed (e.g. MaxConnIdleTime, MaxConnLifetime, Reset, or Close). It cannot prevent the
+	// connection from being closed.
 	BeforeClose func(*pgx.Conn)
 
 	// MaxConnLifetime is the duration since creation after which a connection will be automatically closed.
@@ -144,6 +165,21 @@ type Config struct {
 	// HealthCheckPeriod is the duration between checks of the health of idle connections.
 	HealthCheckPeriod time.Duration
 
+	// ConnectRetries is the number of times a failed attempt to establish a new connection is retried before the error
+	// is returned to Acquire. BeforeConnect is called again for each attempt. The default of 0 does not retry. Errors
+	// that retrying cannot fix are returned immediately. These are server errors for an invalid password (28P01), an
+	// invalid authorization specification (28000), a database that does not exist (3D000), or a missing connect
+	// privilege (42501).
+	ConnectRetries int
+
+	// ConnectRetryBackoff is the delay before the first connection retry. The delay doubles after each failed retry up to
+	// ConnectRetryMaxBackoff. A random jitter of up to 25% is added to each delay so that many pools do not retry in
+	// lockstep. A value of 0 uses the default of 100ms.
+	ConnectRetryBackoff time.Duration
+
+	// ConnectRetryMaxBackoff is the maximum delay between connection retries. A value of 0 uses the default of 5s.
+	ConnectRetryMaxBackoff time.Duration
+
 	createdByParseConfig bool // Used to enforce created by ParseConfig rule.
 }
 
@@ -179,20 +215,24 @@ func NewWithConfig(ctx context.Context, config *Config) (*Pool, error) {
 	}
 
 	p := &Pool{
-		config:                config,
-		beforeConnect:         config.BeforeConnect,
-		afterConnect:          config.AfterConnect,
-		beforeAcquire:         config.BeforeAcquire,
-		afterRelease:          config.AfterRelease,
-		beforeClose:           config.BeforeClose,
-		minConns:              config.MinConns,
-		maxConns:              config.MaxConns,
-		maxConnLifetime:       config.MaxConnLifetime,
-		maxConnLifetimeJitter: config.MaxConnLifetimeJitter,
-		maxConnIdleTime:       config.MaxConnIdleTime,
-		healthCheckPeriod:     config.HealthCheckPeriod,
-		healthCheckChan:       make(chan struct{}, 1),
-		closeChan:             make(chan struct{}),
+		config:                 config,
+		beforeConnect:          config.BeforeConnect,
+		afterConnect:           config.AfterConnect,
+		beforeAcquire:          config.BeforeAcquire,
+		afterRelease:           config.AfterRelease,
+		beforeClose:            config.BeforeClose,
+		minConns:               config.MinConns,
+		maxConns:               config.MaxConns,
+		maxConnLifetime:        config.MaxConnLifetime,
+		maxConnLifetimeJitter:  config.MaxConnLifetimeJitter,
+		maxConnIdleTime:        config.MaxConnIdleTime,
+		healthCheckPeriod:      config.HealthCheckPeriod,
+		connectRetries:         config.ConnectRetries,
+		connectRetryBackoff:    config.ConnectRetryBackoff,
+		connectRetryMaxBackoff: config.ConnectRetryMaxBackoff,
+		healthCheckChan:        make(chan struct{}, 1),
+		allConns:               make(map[*connResource]struct{}),
+		closeChan:              make(chan struct{}),
 	}
 
 	var err error
@@ -200,20 +240,8 @@ func NewWithConfig(ctx context.Context, config *Config) (*Pool, error) {
 		&puddle.Config[*connResource]{
 			Constructor: func(ctx context.Context) (*connResource, error) {
 				atomic.AddInt64(&p.newConnsCount, 1)
-				connConfig := p.config.ConnConfig.Copy()
-
-				// Connection will continue in background even if Acquire is canceled. Ensure that a connect won't hang forever.
-				if connConfig.ConnectTimeout <= 0 {
-					connConfig.ConnectTimeout = 2 * time.Minute
-				}
 
-				if p.beforeConnect != nil {
-					if err := p.beforeConnect(ctx, connConfig); err != nil {
-						return nil, err
-					}
-				}
-
-				conn, err := pgx.ConnectConfig(ctx, connConfig)
+				conn, err := p.connect(ctx)
 				if err != nil {
 					return nil, err
 				}
@@ -227,7 +255,7 @@ func NewWithConfig(ctx context.Context, config *Config) (*Pool, error) {
 				}
 
 				jitterSecs := rand.Float64() * config.MaxConnLifetimeJitter.Seconds()
-				maxAgeTime := time.Now().Add(config.MaxConnLifetime).Add(time.Duration(jitterSecs) * time.Second)
+				maxAgeTime := conn.CreatedAt().Add(config.MaxConnLifetime).Add(time.Duration(jitterSecs) * time.Second)
 
 				cr := &connResource{
 					conn:       conn,
@@ -236,10 +264,12 @@ func NewWithConfig(ctx context.Context, config *Config) (*Pool, error) {
 					poolRowss:  make([]poolRows, 64),
 					maxAgeTime: maxAgeTime,
 				}
+				p.trackConn(cr)
 
 				return cr, nil
 			},
 			Destructor: func(value *connResource) {
+				p.untrackConn(value)
 				ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 				conn := value.conn
 				if p.beforeClose != nil {
@@ -276,6 +306,9 @@ func NewWithConfig(ctx context.Context, config *Config) (*Pool, error) {
 //   - pool_max_conn_idle_time: duration string
 //   - pool_health_check_period: duration string
 //   - pool_max_conn_lifetime_jitter: duration string
+//   - pool_connect_retries: integer 0 or greater
+//   - pool_connect_retry_backoff: duration string
+//   - pool_connect_retry_max_backoff: duration string
 //
 // See Config for definitions of these arguments.
 //
@@ -365,11 +398,120 @@ func ParseConfig(connString string) (*Config, error) {
 		config.MaxConnLifetimeJitter = d
 	}
 
+	if s, ok := config.ConnConfig.Config.RuntimeParams["pool_connect_retries"]; ok {
+		delete(connConfig.Config.RuntimeParams, "pool_connect_retries")
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse pool_connect_retries: %w", err)
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("pool_connect_retries too small: %d", n)
+		}
+		config.ConnectRetries = int(n)
+	}
+
+	if s, ok := config.ConnConfig.Config.RuntimeParams["pool_connect_retry_backoff"]; ok {
+		delete(connConfig.Config.RuntimeParams, "pool_connect_retry_backoff")
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pool_connect_retry_backoff: %w", err)
+		}
+		config.ConnectRetryBackoff = d
+	} else {
+		config.ConnectRetryBackoff = defaultConnectRetryBackoff
+	}
+
+	if s, ok := config.ConnConfig.Config.RuntimeParams["pool_connect_retry_max_backoff"]; ok {
+		delete(connConfig.Config.RuntimeParams, "pool_connect_retry_max_backoff")
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pool_connect_retry_max_backoff: %w", err)
+		}
+		config.ConnectRetryMaxBackoff = d
+	} else {
+		config.ConnectRetryMaxBackoff = defaultConnectRetryMaxBackoff
+	}
+
 	return config, nil
 }
 
+// connect establishes a new connection for the pool. A failed attempt is retried up to connectRetries times unless the
+// error is permanent. The delay before each retry starts at connectRetryBackoff and doubles after each attempt up to
+// connectRetryMaxBackoff. If ctx is canceled while waiting the error from the last attempt is returned.
+func (p *Pool) connect(ctx context.Context) (*pgx.Conn, error) {
+	backoff := p.connectRetryBackoff
+	if backoff <= 0 {
+		backoff = defaultConnectRetryBackoff
+	}
+	maxBackoff := p.connectRetryMaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultConnectRetryMaxBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		conn, err := p.connectOnce(ctx)
+		if err == nil || attempt >= p.connectRetries || isPermanentConnectError(err) {
+			return conn, err
+		}
+
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+		delay := backoff + time.Duration(rand.Int63n(int64(backoff)/4+1))
+		if delay > maxBackoff {
+			delay = maxBackoff
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-p.closeChan:
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// isPermanentConnectError returns true if err is a server error that retrying the connection will not fix.
+func isPermanentConnectError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	switch pgErr.Code {
+	case "28P01", // invalid_password
+		"28000", // invalid_authorization_specification
+		"3D000", // invalid_catalog_name
+		"42501": // insufficient_privilege
+		return true
+	}
+	return false
+}
+
+func (p *Pool) connectOnce(ctx context.Context) (*pgx.Conn, error) {
+	connConfig := p.config.ConnConfig.Copy()
+
+	// Connection will continue in background even if Acquire is canceled. Ensure that a connect won't hang forever.
+	if connConfig.ConnectTimeout <= 0 {
+		connConfig.ConnectTimeout = 2 * time.Minute
+	}
+
+	if p.beforeConnect != nil {
+		if err := p.beforeConnect(ctx, connConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	return pgx.ConnectConfig(ctx, connConfig)
+}
+
 // Close closes all connections in the pool and rejects future Acquire calls. Blocks until all connections are returned
-// to pool and closed.
+// to pool and closed. Calling Drain before Close allows in-flight work to finish while no new work is started.
 func (p *Pool) Close() {
 	p.closeOnce.Do(func() {
 		close(p.closeChan)
@@ -377,6 +519,79 @@ func (p *Pool) Close() {
 	})
 }
 
+// CloseForce closes the pool like Close but does not wait indefinitely for acquired connections. It first stops new
+// connections from being acquired and closes all idle connections. It then sends a cancel request for every acquired
+// connection to interrupt any query in progress. It waits for the acquired connections to be released until ctx is
+// done. After that the network connection of any connection that is still acquired is closed. This causes any
+// operation in progress on it to fail. CloseForce returns when all connections are released and closed.
+//
+// Connections must still be released. CloseForce will block forever if an acquired connection is never released.
+func (p *Pool) CloseForce(ctx context.Context) {
+	p.Drain()
+
+	for _, res := range p.p.AcquireAllIdle() {
+		res.Destroy()
+	}
+
+	var wg sync.WaitGroup
+	for _, cr := range p.trackedConns() {
+		wg.Add(1)
+		go func(cr *connResource) {
+			defer wg.Done()
+			cr.conn.PgConn().CancelRequest(ctx)
+		}(cr)
+	}
+	wg.Wait()
+
+	closed := make(chan struct{})
+	go func() {
+		p.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+		return
+	case <-ctx.Done():
+	}
+
+	for _, cr := range p.trackedConns() {
+		cr.conn.PgConn().Conn().Close()
+	}
+
+	<-closed
+}
+
+func (p *Pool) trackConn(cr *connResource) {
+	p.allConnsMux.Lock()
+	p.allConns[cr] = struct{}{}
+	p.allConnsMux.Unlock()
+}
+
+func (p *Pool) untrackConn(cr *connResource) {
+	p.allConnsMux.Lock()
+	delete(p.allConns, cr)
+	p.allConnsMux.Unlock()
+}
+
+func (p *Pool) trackedConns() []*connResource {
+	p.allConnsMux.Lock()
+	defer p.allConnsMux.Unlock()
+
+	conns := make([]*connResource, 0, len(p.allConns))
+	for cr := range p.allConns {
+		conns = append(conns, cr)
+	}
+	return conns
+}
+
+// Drain prevents any new connections from being acquired from the pool. Acquire and methods that acquire a connection
+// will return ErrPoolClosed. Connections that are already acquired can continue to be used and released normally. Drain
+// does not block. Call Close to wait for all acquired connections to be released and closed.
+func (p *Pool) Drain() {
+	p.draining.Store(true)
+}
+
 func (p *Pool) isExpired(res *puddle.Resource[*connResource]) bool {
 	return time.Now().After(res.Value().maxAgeTime)
 }
@@ -458,6 +673,11 @@ func (p *Pool) checkConnsHealth() bool {
 }
 
 func (p *Pool) checkMinConns() error {
+	// A draining pool is winding down so it should not create new connections.
+	if p.draining.Load() {
+		return nil
+	}
+
 	// TotalConns can include ones that are being destroyed but we should have
 	// sleep(500ms) around all of the destroys to help prevent that from throwing
 	// off this check
@@ -497,8 +717,14 @@ func (p *Pool) createIdleResources(parentCtx context.Context, targetResources in
 	return firstError
 }
 
-// Acquire returns a connection (*Conn) from the Pool
+// Acquire returns a connection (*Conn) from the Pool. If no connection is available Acquire waits until one is
+// released or ctx is done. Waiting Acquire calls are served in FIFO order so the longest waiting caller receives the
+// next available connection.
 func (p *Pool) Acquire(ctx context.Context) (*Conn, error) {
+	if p.draining.Load() {
+		return nil, ErrPoolClosed
+	}
+
 	for {
 		res, err := p.p.Acquire(ctx)
 		if err != nil {
@@ -525,7 +751,10 @@ func (p *Pool) Acquire(ctx context.Context) (*Conn, error) {
 
 // AcquireFunc acquires a *Conn and calls f with that *Conn. ctx will only affect the Acquire. It has no effect on the
 // call of f. The return value is either an error acquiring the *Conn or the return value of f. The *Conn is
-// automatically released after the call of f.
+// automatically released after the call of f, even if f panics.
+//
+// AcquireFunc is the recommended way to run several statements on a single connection, such as session setup
+// followed by a query or a transaction that needs the *pgx.Conn via Conn.Conn.
 func (p *Pool) AcquireFunc(ctx context.Context, f func(*Conn) error) error {
 	conn, err := p.Acquire(ctx)
 	if err != nil {
@@ -536,9 +765,36 @@ func (p *Pool) AcquireFunc(ctx context.Context, f func(*Conn) error) error {
 	return f(conn)
 }
 
+// AcquireAs acquires a *Conn and sets its current role to role with SET ROLE. This can be used to switch roles per
+// request, for example to apply row-level security policies. When the *Conn is released RESET ROLE is run before it is
+// returned to the pool. If RESET ROLE fails the connection is destroyed. If SET ROLE fails the *Conn is released and
+// the error is returned.
+//
+// The role is only reset by Release. A role changed by the caller with SET ROLE or SET SESSION AUTHORIZATION on a *Conn
+// acquired with Acquire is not reset.
+func (p *Pool) AcquireAs(ctx context.Context, role string) (*Conn, error) {
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.Exec(ctx, "set role "+pgx.Identifier{role}.Sanitize())
+	if err != nil {
+		c.Release()
+		return nil, err
+	}
+	c.res.Value().resetRole = true
+
+	return c, nil
+}
+
 // AcquireAllIdle atomically acquires all currently idle connections. Its intended use is for health check and
 // keep-alive functionality. It does not update pool statistics.
 func (p *Pool) AcquireAllIdle(ctx context.Context) []*Conn {
+	if p.draining.Load() {
+		return nil
+	}
+
 	resources := p.p.AcquireAllIdle()
 	conns := make([]*Conn, 0, len(resources))
 	for _, res := range resources {
@@ -685,6 +941,9 @@ func (p *Pool) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNam
 
 // Ping acquires a connection from the Pool and executes an empty sql statement against it.
 // If the sql returns without error, the database Ping is considered successful, otherwise, the error is returned.
+//
+// ctx bounds both acquiring the connection and the ping itself. When used as a health check, ctx should have a deadline
+// so that the check cannot hang when all connections are in use or the server is unresponsive.
 func (p *Pool) Ping(ctx context.Context) error {
 	c, err := p.Acquire(ctx)
 	if err != nil {
@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"runtime"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/pgxtest"
 	"github.com/stretchr/testify/assert"
@@ -43,12 +46,23 @@ func TestConnectConfig(t *testing.T) {
 func TestParseConfigExtractsPoolArguments(t *testing.T) {
 	t.Parallel()
 
-	config, err := pgxpool.ParseConfig("pool_max_conns=42 pool_min_conns=1")
+	config, err := pgxpool.ParseConfig("pool_max_conns=42 pool_min_conns=1 pool_connect_retries=3 pool_connect_retry_backoff=250ms pool_connect_retry_max_backoff=2s")
 	assert.NoError(t, err)
 	assert.EqualValues(t, 42, config.MaxConns)
 	assert.EqualValues(t, 1, config.MinConns)
+	assert.Equal(t, 3, config.ConnectRetries)
+	assert.Equal(t, 250*time.Millisecond, config.ConnectRetryBackoff)
+	assert.Equal(t, 2*time.Second, config.ConnectRetryMaxBackoff)
 	assert.NotContains(t, config.ConnConfig.Config.RuntimeParams, "pool_max_conns")
 	assert.NotContains(t, config.ConnConfig.Config.RuntimeParams, "pool_min_conns")
+	assert.NotContains(t, config.ConnConfig.Config.RuntimeParams, "pool_connect_retries")
+	assert.NotContains(t, config.ConnConfig.Config.RuntimeParams, "pool_connect_retry_backoff")
+	assert.NotContains(t, config.ConnConfig.Config.RuntimeParams, "pool_connect_retry_max_backoff")
+
+	config, err = pgxpool.ParseConfig("")
+	assert.NoError(t, err)
+	assert.Equal(t, 100*time.Millisecond, config.ConnectRetryBackoff)
+	assert.Equal(t, 5*time.Second, config.ConnectRetryMaxBackoff)
 }
 
 func TestConstructorIgnoresContext(t *testing.T) {
@@ -203,6 +217,76 @@ func TestPoolAcquireChecksIdleConns(t *testing.T) {
 	require.NotContains(t, pids, cPID)
 }
 
+func TestPoolAcquireIsFair(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.MaxConns = 2
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	const workerCount = 10
+	acquireCounts := make([]int64, workerCount)
+	stop := make(chan struct{})
+	errChan := make(chan error, workerCount)
+
+	for i := 0; i < workerCount; i++ {
+		go func(i int) {
+			for {
+				select {
+				case <-stop:
+					errChan <- nil
+					return
+				default:
+				}
+
+				c, err := pool.Acquire(ctx)
+				if err != nil {
+					errChan <- err
+					return
+				}
+				// Hold the connection for a round trip so the other workers queue behind it.
+				_, err = c.Exec(ctx, "select 1")
+				c.Release()
+				if err != nil {
+					errChan <- err
+					return
+				}
+				atomic.AddInt64(&acquireCounts[i], 1)
+			}
+		}(i)
+	}
+
+	// Run until enough acquires had to wait for a connection rather than for a fixed amount of time.
+	const minEmptyAcquires = 1000
+	for pool.Stat().EmptyAcquireCount() < minEmptyAcquires {
+		require.NoError(t, ctx.Err())
+		runtime.Gosched()
+	}
+	close(stop)
+	for i := 0; i < workerCount; i++ {
+		require.NoError(t, <-errChan)
+	}
+
+	var total, min int64
+	for i, n := range acquireCounts {
+		total += n
+		if i == 0 || n < min {
+			min = n
+		}
+	}
+	mean := total / workerCount
+
+	// With FIFO waiters no worker should be starved. Allow generous variance for scheduling noise.
+	require.Greaterf(t, min, mean/4, "acquire counts: %v", acquireCounts)
+}
+
 func TestPoolAcquireFunc(t *testing.T) {
 	t.Parallel()
 
@@ -237,6 +321,109 @@ func TestPoolAcquireFuncReturnsFnError(t *testing.T) {
 	require.EqualError(t, err, "some error")
 }
 
+func TestPoolAcquireFuncTransaction(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	defer pool.Close()
+
+	var n int32
+	err = pool.AcquireFunc(ctx, func(c *pgxpool.Conn) error {
+		return pgx.BeginFunc(ctx, c, func(tx pgx.Tx) error {
+			_, err := tx.Exec(ctx, "create temporary table t(n int4) on commit drop")
+			if err != nil {
+				return err
+			}
+			_, err = tx.Exec(ctx, "insert into t(n) values($1), ($2)", 1, 2)
+			if err != nil {
+				return err
+			}
+			return tx.QueryRow(ctx, "select sum(n) from t").Scan(&n)
+		})
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, n)
+
+	waitForReleaseToComplete()
+	require.EqualValues(t, 0, pool.Stat().AcquiredConns())
+}
+
+func TestPoolAcquireFuncReleasesOnPanic(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	defer pool.Close()
+
+	require.Panics(t, func() {
+		pool.AcquireFunc(ctx, func(c *pgxpool.Conn) error {
+			panic("boom")
+		})
+	})
+
+	waitForReleaseToComplete()
+	require.EqualValues(t, 0, pool.Stat().AcquiredConns())
+
+	var n int32
+	err = pool.QueryRow(ctx, "select 1").Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+}
+
+func TestPoolAcquireAs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.MaxConns = 1
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	var sessionUser string
+	err = pool.QueryRow(ctx, "select session_user").Scan(&sessionUser)
+	require.NoError(t, err)
+
+	c, err := pool.AcquireAs(ctx, "pgxpool role that does not exist")
+	require.Error(t, err)
+	require.Nil(t, c)
+	waitForReleaseToComplete()
+	require.EqualValues(t, 0, pool.Stat().AcquiredConns())
+
+	role := fmt.Sprintf("pgxpool_acquire_as_%d", time.Now().UnixNano())
+	_, err = pool.Exec(ctx, "create role "+pgx.Identifier{role}.Sanitize())
+	if err != nil {
+		t.Skipf("Skipping due to unable to create role: %v", err)
+	}
+	defer pool.Exec(context.Background(), "drop role "+pgx.Identifier{role}.Sanitize())
+
+	c, err = pool.AcquireAs(ctx, role)
+	require.NoError(t, err)
+	var currentUser string
+	err = c.QueryRow(ctx, "select current_user").Scan(&currentUser)
+	require.NoError(t, err)
+	require.Equal(t, role, currentUser)
+	c.Release()
+
+	waitForReleaseToComplete()
+
+	// MaxConns is 1 so this is the same connection.
+	err = pool.QueryRow(ctx, "select current_user").Scan(&currentUser)
+	require.NoError(t, err)
+	require.Equal(t, sessionUser, currentUser)
+}
+
 func TestPoolBeforeConnect(t *testing.T) {
 	t.Parallel()
 
@@ -261,6 +448,186 @@ func TestPoolBeforeConnect(t *testing.T) {
 	assert.EqualValues(t, "pgx", str)
 }
 
+func TestPoolBeforeConnectCalledForEachConnection(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+
+	password := config.ConnConfig.Password
+	var calls int32
+	config.BeforeConnect = func(ctx context.Context, cfg *pgx.ConnConfig) error {
+		n := atomic.AddInt32(&calls, 1)
+		if n > 2 {
+			return errors.New("token fetch failed")
+		}
+		cfg.Password = password
+		cfg.Config.RuntimeParams["application_name"] = fmt.Sprintf("pgx-%d", n)
+		return nil
+	}
+
+	db, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	c1, err := db.Acquire(ctx)
+	require.NoError(t, err)
+	defer c1.Release()
+
+	c2, err := db.Acquire(ctx)
+	require.NoError(t, err)
+	defer c2.Release()
+
+	var names []string
+	for _, c := range []*pgxpool.Conn{c1, c2} {
+		var name string
+		err = c.QueryRow(ctx, "SHOW application_name").Scan(&name)
+		require.NoError(t, err)
+		names = append(names, name)
+	}
+	require.ElementsMatch(t, []string{"pgx-1", "pgx-2"}, names)
+
+	_, err = db.Acquire(ctx)
+	require.ErrorContains(t, err, "token fetch failed")
+}
+
+func TestPoolConnectRetriesWithBackoff(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.ConnectRetries = 3
+	config.ConnectRetryBackoff = 50 * time.Millisecond
+
+	var mux sync.Mutex
+	var attempts []time.Time
+	connectErr := errors.New("database is down")
+	config.BeforeConnect = func(context.Context, *pgx.ConnConfig) error {
+		mux.Lock()
+		defer mux.Unlock()
+		attempts = append(attempts, time.Now())
+		return connectErr
+	}
+
+	db, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Acquire(ctx)
+	require.ErrorIs(t, err, connectErr)
+
+	mux.Lock()
+	defer mux.Unlock()
+	require.Len(t, attempts, 4)
+	for i, expected := range []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond} {
+		assert.GreaterOrEqualf(t, attempts[i+1].Sub(attempts[i]), expected, "%d", i)
+	}
+}
+
+func TestPoolConnectRetriesMaxBackoff(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.ConnectRetries = 4
+	config.ConnectRetryBackoff = 20 * time.Millisecond
+	config.ConnectRetryMaxBackoff = 40 * time.Millisecond
+
+	var mux sync.Mutex
+	var attempts []time.Time
+	connectErr := errors.New("database is down")
+	config.BeforeConnect = func(context.Context, *pgx.ConnConfig) error {
+		mux.Lock()
+		defer mux.Unlock()
+		attempts = append(attempts, time.Now())
+		return connectErr
+	}
+
+	db, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Acquire(ctx)
+	require.ErrorIs(t, err, connectErr)
+
+	mux.Lock()
+	defer mux.Unlock()
+	require.Len(t, attempts, 5)
+	for i, expected := range []time.Duration{20 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond} {
+		assert.GreaterOrEqualf(t, attempts[i+1].Sub(attempts[i]), expected, "%d", i)
+	}
+	// Without the cap the last delay alone would be 160ms.
+	assert.Less(t, attempts[4].Sub(attempts[3]), 150*time.Millisecond)
+}
+
+func TestPoolConnectRetriesSkipsPermanentErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	for _, code := range []string{"28P01", "28000", "3D000", "42501"} {
+		config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+		require.NoError(t, err)
+		config.ConnectRetries = 3
+		config.ConnectRetryBackoff = 10 * time.Millisecond
+
+		var calls int32
+		connectErr := fmt.Errorf("connect failed: %w", &pgconn.PgError{Severity: "FATAL", Code: code})
+		config.BeforeConnect = func(context.Context, *pgx.ConnConfig) error {
+			atomic.AddInt32(&calls, 1)
+			return connectErr
+		}
+
+		db, err := pgxpool.NewWithConfig(ctx, config)
+		require.NoError(t, err)
+
+		_, err = db.Acquire(ctx)
+		require.ErrorIsf(t, err, connectErr, "%s", code)
+		require.EqualValuesf(t, 1, atomic.LoadInt32(&calls), "%s", code)
+
+		db.Close()
+	}
+}
+
+func TestPoolConnectRetriesRecoverFromTransientFailures(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.ConnectRetries = 2
+	config.ConnectRetryBackoff = 10 * time.Millisecond
+
+	var calls int32
+	config.BeforeConnect = func(context.Context, *pgx.ConnConfig) error {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			return errors.New("database is down")
+		}
+		return nil
+	}
+
+	db, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	err = db.Ping(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	require.EqualValues(t, 1, db.Stat().NewConnsCount())
+}
+
 func TestPoolAfterConnect(t *testing.T) {
 	t.Parallel()
 
@@ -368,6 +735,32 @@ func TestPoolAfterRelease(t *testing.T) {
 	assert.EqualValues(t, 5, len(connPIDs))
 }
 
+func TestPoolPingRespectsContextWhenPoolExhausted(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.MaxConns = 1
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	require.NoError(t, pool.Ping(ctx))
+
+	c, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+	defer c.Release()
+
+	pingCtx, pingCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer pingCancel()
+	err = pool.Ping(pingCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func TestPoolBeforeClose(t *testing.T) {
 	t.Parallel()
 
@@ -406,6 +799,56 @@ func TestPoolBeforeClose(t *testing.T) {
 	assert.ElementsMatch(t, acquiredPIDs, closedPIDs)
 }
 
+func TestPoolBeforeCloseCalledForEachCloseReason(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+
+	config.MaxConnLifetime = 1 * time.Minute
+	config.MaxConnIdleTime = 100 * time.Millisecond
+	config.HealthCheckPeriod = 100 * time.Millisecond
+
+	connPIDs := make(chan uint32, 5)
+	config.BeforeClose = func(c *pgx.Conn) {
+		connPIDs <- c.PgConn().PID()
+	}
+
+	db, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Idle reaping
+	c, err := db.Acquire(ctx)
+	require.NoError(t, err)
+	idlePID := c.Conn().PgConn().PID()
+	c.Release()
+
+	select {
+	case pid := <-connPIDs:
+		require.Equal(t, idlePID, pid)
+	case <-ctx.Done():
+		t.Fatal("BeforeClose not called for idle connection")
+	}
+
+	// Pool close
+	c, err = db.Acquire(ctx)
+	require.NoError(t, err)
+	closePID := c.Conn().PgConn().PID()
+	c.Release()
+	db.Close()
+
+	select {
+	case pid := <-connPIDs:
+		require.Equal(t, closePID, pid)
+	case <-ctx.Done():
+		t.Fatal("BeforeClose not called on pool close")
+	}
+}
+
 func TestPoolAcquireAllIdle(t *testing.T) {
 	t.Parallel()
 
@@ -465,6 +908,122 @@ func TestPoolReset(t *testing.T) {
 	require.EqualValues(t, 0, db.Stat().TotalConns())
 }
 
+func TestPoolDrain(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	db, err := pgxpool.New(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	c, err := db.Acquire(ctx)
+	require.NoError(t, err)
+
+	db.Drain()
+
+	_, err = db.Acquire(ctx)
+	require.ErrorIs(t, err, pgxpool.ErrPoolClosed)
+
+	_, err = db.Exec(ctx, "select 1")
+	require.ErrorIs(t, err, pgxpool.ErrPoolClosed)
+
+	// The already acquired connection is still usable.
+	_, err = c.Exec(ctx, "select 1")
+	require.NoError(t, err)
+
+	closeDone := make(chan struct{})
+	go func() {
+		db.Close()
+		close(closeDone)
+	}()
+
+	select {
+	case <-closeDone:
+		t.Fatal("Close returned before acquired connection was released")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	c.Release()
+
+	select {
+	case <-closeDone:
+	case <-ctx.Done():
+		t.Fatal("Close did not return after acquired connection was released")
+	}
+}
+
+func TestPoolCloseForceCancelsQueries(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	db, err := pgxpool.New(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+
+	idle, err := db.Acquire(ctx)
+	require.NoError(t, err)
+	idle.Release()
+
+	c, err := db.Acquire(ctx)
+	require.NoError(t, err)
+
+	queryErrChan := make(chan error)
+	go func() {
+		defer c.Release()
+		_, err := c.Exec(ctx, "select pg_sleep(60)")
+		queryErrChan <- err
+	}()
+
+	// Give the query time to start.
+	time.Sleep(500 * time.Millisecond)
+
+	start := time.Now()
+	closeCtx, closeCancel := context.WithTimeout(ctx, 10*time.Second)
+	defer closeCancel()
+	db.CloseForce(closeCtx)
+	require.Less(t, time.Since(start), 30*time.Second)
+
+	require.Error(t, <-queryErrChan)
+
+	_, err = db.Acquire(ctx)
+	require.ErrorIs(t, err, pgxpool.ErrPoolClosed)
+}
+
+func TestPoolCloseForceClosesConnsAfterContextDone(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	db, err := pgxpool.New(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+
+	c, err := db.Acquire(ctx)
+	require.NoError(t, err)
+
+	// The connection is acquired but idle so the cancel request has no effect. It is only released once its network
+	// connection is closed.
+	go func() {
+		defer c.Release()
+		for {
+			_, err := c.Exec(ctx, "select 1")
+			if err != nil {
+				return
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}()
+
+	closeCtx, closeCancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer closeCancel()
+	db.CloseForce(closeCtx)
+
+	require.NoError(t, ctx.Err())
+}
+
 func TestConnReleaseChecksMaxConnLifetime(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,74 @@
+package pgxpool
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// ReadWritePool routes connections between a writer Pool, typically connected to a primary server, and zero or more
+// reader Pools, typically connected to replicas. AcquireWrite always uses the writer. AcquireRead round-robins across
+// the readers and falls back to the writer when there are no readers.
+//
+// ReadWritePool does not own any connections itself. Each Pool retains its own configuration and statistics.
+type ReadWritePool struct {
+	writer  *Pool
+	readers []*Pool
+	next    atomic.Uint32
+}
+
+// NewReadWritePool creates a ReadWritePool from writer and readers. writer must not be nil.
+func NewReadWritePool(writer *Pool, readers ...*Pool) *ReadWritePool {
+	return &ReadWritePool{
+		writer:  writer,
+		readers: readers,
+	}
+}
+
+// AcquireWrite acquires a *Conn from the writer Pool.
+func (p *ReadWritePool) AcquireWrite(ctx context.Context) (*Conn, error) {
+	return p.writer.Acquire(ctx)
+}
+
+// AcquireRead acquires a *Conn from the next reader Pool in round-robin order. If acquiring from a reader fails, such
+// as when its server is down, the following readers are tried in turn. If every reader fails the error from the last
+// reader tried is returned. If ctx is done no further readers are tried. If there are no readers the connection is
+// acquired from the writer Pool.
+func (p *ReadWritePool) AcquireRead(ctx context.Context) (*Conn, error) {
+	if len(p.readers) == 0 {
+		return p.writer.Acquire(ctx)
+	}
+
+	start := int((p.next.Add(1) - 1) % uint32(len(p.readers)))
+
+	var err error
+	for i := 0; i < len(p.readers); i++ {
+		var conn *Conn
+		conn, err = p.readers[(start+i)%len(p.readers)].Acquire(ctx)
+		if err == nil {
+			return conn, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+// Writer returns the writer Pool.
+func (p *ReadWritePool) Writer() *Pool {
+	return p.writer
+}
+
+// Readers returns the reader Pools. The returned slice must not be modified.
+func (p *ReadWritePool) Readers() []*Pool {
+	return p.readers
+}
+
+// Close closes the writer and all reader Pools.
+func (p *ReadWritePool) Close() {
+	p.writer.Close()
+	for _, r := range p.readers {
+		r.Close()
+	}
+}
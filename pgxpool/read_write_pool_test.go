@@ -0,0 +1,118 @@
+package pgxpool_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errReaderDown = errors.New("reader down")
+
+func newDownPool(t testing.TB, ctx context.Context) *pgxpool.Pool {
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.BeforeConnect = func(context.Context, *pgx.ConnConfig) error {
+		return errReaderDown
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	return pool
+}
+
+func TestReadWritePoolAcquireReadRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	writer, err := pgxpool.New(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	reader1, err := pgxpool.New(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	reader2, err := pgxpool.New(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+
+	rwp := pgxpool.NewReadWritePool(writer, reader1, reader2)
+	defer rwp.Close()
+
+	for i := 0; i < 4; i++ {
+		c, err := rwp.AcquireRead(ctx)
+		require.NoError(t, err)
+		c.Release()
+	}
+
+	c, err := rwp.AcquireWrite(ctx)
+	require.NoError(t, err)
+	c.Release()
+
+	assert.EqualValues(t, 1, writer.Stat().AcquireCount())
+	assert.EqualValues(t, 2, reader1.Stat().AcquireCount())
+	assert.EqualValues(t, 2, reader2.Stat().AcquireCount())
+}
+
+func TestReadWritePoolAcquireReadSkipsDownReader(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	writer, err := pgxpool.New(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	down := newDownPool(t, ctx)
+	up, err := pgxpool.New(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+
+	rwp := pgxpool.NewReadWritePool(writer, down, up)
+	defer rwp.Close()
+
+	for i := 0; i < 3; i++ {
+		c, err := rwp.AcquireRead(ctx)
+		require.NoError(t, err)
+		c.Release()
+	}
+
+	assert.EqualValues(t, 0, writer.Stat().AcquireCount())
+	assert.EqualValues(t, 3, up.Stat().AcquireCount())
+}
+
+func TestReadWritePoolAcquireReadAllReadersDown(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	rwp := pgxpool.NewReadWritePool(newDownPool(t, ctx), newDownPool(t, ctx), newDownPool(t, ctx))
+	defer rwp.Close()
+
+	c, err := rwp.AcquireRead(ctx)
+	require.ErrorIs(t, err, errReaderDown)
+	require.Nil(t, c)
+}
+
+func TestReadWritePoolAcquireReadWithoutReadersUsesWriter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	writer, err := pgxpool.New(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+
+	rwp := pgxpool.NewReadWritePool(writer)
+	defer rwp.Close()
+
+	c, err := rwp.AcquireRead(ctx)
+	require.NoError(t, err)
+	c.Release()
+
+	assert.EqualValues(t, 1, writer.Stat().AcquireCount())
+	assert.Same(t, writer, rwp.Writer())
+	assert.Empty(t, rwp.Readers())
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"os"
@@ -69,6 +70,24 @@ func TestConnQueryRowsFieldDescriptionsBeforeNext(t *testing.T) {
 	assert.Equal(t, "msg", rows.FieldDescriptions()[0].Name)
 }
 
+func TestConnQueryWhileRowsOpenReturnsErrConnBusy(t *testing.T) {
+	t.Parallel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	rows, err := conn.Query(context.Background(), "select generate_series(1, 10)")
+	require.NoError(t, err)
+
+	_, err = conn.Exec(context.Background(), "select 1")
+	require.ErrorIs(t, err, pgx.ErrConnBusy)
+
+	rows.Close()
+	require.NoError(t, rows.Err())
+
+	ensureConnValid(t, conn)
+}
+
 func TestConnQueryWithoutResultSetCommandTag(t *testing.T) {
 	t.Parallel()
 
@@ -238,6 +257,31 @@ func TestConnQueryArgsAndScanWithUnregisteredOID(t *testing.T) {
 	})
 }
 
+func TestConnQueryUnregisteredOIDWithRegisteredColumns(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		tx, err := conn.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		_, err = tx.Exec(ctx, "create type fruit as enum('orange', 'apple', 'pear')")
+		require.NoError(t, err)
+
+		var n int32
+		var fruit string
+		var b []byte
+		err = tx.QueryRow(ctx, "select 42::int4, 'pear'::fruit, '\\x010203'::bytea").Scan(&n, &fruit, &b)
+		require.NoError(t, err)
+		require.EqualValues(t, 42, n)
+		require.Equal(t, "pear", fruit)
+		require.Equal(t, []byte{1, 2, 3}, b)
+	})
+}
+
 // https://github.com/jackc/pgx/issues/478
 func TestConnQueryReadRowMultipleTimes(t *testing.T) {
 	t.Parallel()
@@ -335,6 +379,65 @@ func TestConnQueryRawValues(t *testing.T) {
 	assert.EqualValues(t, 10, rowCount)
 }
 
+func TestConnQueryRawValuesBinaryByteaToWriter(t *testing.T) {
+	t.Parallel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	blob := make([]byte, 256*1024)
+	for i := range blob {
+		blob[i] = byte(i * 7)
+	}
+
+	rows, err := conn.Query(
+		context.Background(),
+		"select $1::bytea",
+		pgx.QueryResultFormats{pgx.BinaryFormatCode},
+		blob,
+	)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var buf bytes.Buffer
+	for rows.Next() {
+		fds := rows.FieldDescriptions()
+		require.Len(t, fds, 1)
+		require.EqualValues(t, pgtype.ByteaOID, fds[0].DataTypeOID)
+		require.EqualValues(t, pgx.BinaryFormatCode, fds[0].Format)
+
+		_, err := buf.Write(rows.RawValues()[0])
+		require.NoError(t, err)
+	}
+	require.NoError(t, rows.Err())
+
+	require.Equal(t, blob, buf.Bytes())
+	ensureConnValid(t, conn)
+}
+
+func TestConnQueryVarcharParameterTooLong(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var s string
+		err := conn.QueryRow(ctx, "select $1::varchar(10)", "0123456789").Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "0123456789", s)
+
+		mustExec(t, conn, "create temporary table t(s varchar(10))")
+
+		_, err = conn.Exec(ctx, "insert into t(s) values($1)", "0123456789a")
+		var pgErr *pgconn.PgError
+		require.ErrorAs(t, err, &pgErr)
+		require.Equal(t, "22001", pgErr.Code)
+
+		ensureConnValid(t, conn)
+	})
+}
+
 // Test that a connection stays valid when query results are closed early
 func TestConnQueryCloseEarly(t *testing.T) {
 	t.Parallel()
@@ -389,6 +492,43 @@ func TestConnQueryCloseEarlyWithErrorOnWire(t *testing.T) {
 	ensureConnValid(t, conn)
 }
 
+// Every query method drains the connection to ReadyForQuery when the server reports an error so the connection must
+// be immediately usable for the next query.
+func TestConnUsableAfterPgErrorMidStream(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server uses numeric instead of int")
+
+		sql := "select 10/(10-n) from generate_series(1, 100) n"
+		var pgErr *pgconn.PgError
+
+		_, err := conn.Exec(ctx, sql)
+		require.ErrorAs(t, err, &pgErr)
+		ensureConnValid(t, conn)
+
+		rows, _ := conn.Query(ctx, sql)
+		_, err = pgx.CollectRows(rows, pgx.RowTo[int32])
+		require.ErrorAs(t, err, &pgErr)
+		ensureConnValid(t, conn)
+
+		var n int32
+		err = conn.QueryRow(ctx, sql).Scan(&n)
+		require.ErrorAs(t, err, &pgErr)
+		ensureConnValid(t, conn)
+
+		batch := &pgx.Batch{}
+		batch.Queue(sql)
+		batch.Queue("select 1")
+		err = conn.SendBatch(ctx, batch).Close()
+		require.ErrorAs(t, err, &pgErr)
+		ensureConnValid(t, conn)
+	})
+}
+
 // Test that a connection stays valid when query results read incorrectly
 func TestConnQueryReadWrongTypeError(t *testing.T) {
 	t.Parallel()
@@ -534,6 +674,47 @@ insert into t (id, n) values ('a', 1), ('b', 2), ('c', 3);`)
 	ensureConnValid(t, conn)
 }
 
+func TestConnQueryReturningClause(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		mustExec(t, conn, `create temporary table returning_test(id smallserial primary key, name text not null, n int4 not null)`)
+
+		var id int16
+		err := conn.QueryRow(ctx, "insert into returning_test(name, n) values($1, $2) returning id", "foo", 1).Scan(&id)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, id)
+
+		var name string
+		var n int32
+		err = conn.QueryRow(ctx, "insert into returning_test(name, n) values($1, $2) returning id, name, n", "bar", 2).Scan(&id, &name, &n)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, id)
+		require.Equal(t, "bar", name)
+		require.EqualValues(t, 2, n)
+
+		rows, _ := conn.Query(ctx, "update returning_test set n = n * 10 returning id, n")
+		type idN struct {
+			ID int16
+			N  int32
+		}
+		updated, err := pgx.CollectRows(rows, pgx.RowToStructByPos[idN])
+		require.NoError(t, err)
+		require.ElementsMatch(t, []idN{{ID: 1, N: 10}, {ID: 2, N: 20}}, updated)
+		require.Equal(t, "UPDATE 2", rows.CommandTag().String())
+
+		err = conn.QueryRow(ctx, "delete from returning_test where id = $1 returning name", 1).Scan(&name)
+		require.NoError(t, err)
+		require.Equal(t, "foo", name)
+
+		err = conn.QueryRow(ctx, "delete from returning_test where id = $1 returning name", 1).Scan(&name)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
+
 func TestConnQueryErrorWhileReturningRows(t *testing.T) {
 	t.Parallel()
 
@@ -592,6 +773,27 @@ func TestQueryEncodeError(t *testing.T) {
 	}
 }
 
+func TestQueryUnsupportedParameterType(t *testing.T) {
+	t.Parallel()
+
+	type unsupported struct {
+		A int
+		B string
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		_, err := conn.Exec(ctx, "select $1::int4, $2::text", 1, unsupported{A: 1, B: "foo"})
+		require.ErrorIs(t, err, pgx.ErrUnsupportedParameterType)
+		require.Contains(t, err.Error(), "args[1]")
+		require.Contains(t, err.Error(), "pgx_test.unsupported")
+
+		ensureConnValid(t, conn)
+	})
+}
+
 func TestQueryRowCoreTypes(t *testing.T) {
 	t.Parallel()
 
@@ -1023,6 +1225,35 @@ func TestQueryRowNoResults(t *testing.T) {
 	ensureConnValid(t, conn)
 }
 
+func TestQueryRowScanReleasesConn(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		// Only the first row is scanned. The rest are discarded.
+		var n int32
+		var s string
+		err := conn.QueryRow(ctx, "select n, n::text from generate_series(1, 10) n").Scan(&n, &s)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, n)
+		require.Equal(t, "1", s)
+		ensureConnValid(t, conn)
+
+		err = conn.QueryRow(ctx, "select n from generate_series(1, 10) n where n > $1", 10).Scan(&n)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		ensureConnValid(t, conn)
+
+		// Errors from sending the query are returned by Scan.
+		err = conn.QueryRow(ctx, "select * from table_that_does_not_exist").Scan(&n)
+		var pgErr *pgconn.PgError
+		require.ErrorAs(t, err, &pgErr)
+		require.Equal(t, "42P01", pgErr.Code)
+		ensureConnValid(t, conn)
+	})
+}
+
 func TestQueryRowEmptyQuery(t *testing.T) {
 	t.Parallel()
 
@@ -1150,6 +1381,31 @@ func TestConnQueryDatabaseSQLDriverValuer(t *testing.T) {
 	ensureConnValid(t, conn)
 }
 
+type cents int64
+
+func (c cents) Value() (driver.Value, error) {
+	if c < 0 {
+		return nil, nil
+	}
+	return fmt.Sprintf("%d.%02d", c/100, c%100), nil
+}
+
+func TestConnQueryCustomDatabaseSQLDriverValuer(t *testing.T) {
+	t.Parallel()
+
+	pgxtest.RunWithQueryExecModes(context.Background(), t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var s string
+		err := conn.QueryRow(ctx, "select $1::numeric::text", cents(12345)).Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "123.45", s)
+
+		var isNull bool
+		err = conn.QueryRow(ctx, "select $1::numeric is null", cents(-1)).Scan(&isNull)
+		require.NoError(t, err)
+		require.True(t, isNull)
+	})
+}
+
 // https://github.com/jackc/pgx/issues/339
 func TestConnQueryDatabaseSQLDriverValuerWithAutoGeneratedPointerReceiver(t *testing.T) {
 	t.Parallel()
@@ -1288,6 +1544,96 @@ func TestConnQueryDatabaseSQLNullX(t *testing.T) {
 	ensureConnValid(t, conn)
 }
 
+// nullableCount is a third-party style null type that only implements sql.Scanner and driver.Valuer.
+type nullableCount struct {
+	n     int64
+	valid bool
+}
+
+func (c *nullableCount) Scan(src any) error {
+	if src == nil {
+		*c = nullableCount{}
+		return nil
+	}
+	n, ok := src.(int64)
+	if !ok {
+		return fmt.Errorf("cannot scan %T", src)
+	}
+	*c = nullableCount{n: n, valid: true}
+	return nil
+}
+
+func (c nullableCount) Value() (driver.Value, error) {
+	if !c.valid {
+		return nil, nil
+	}
+	return c.n, nil
+}
+
+func TestConnInsertNullTypesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		mustExec(t, conn, `create temporary table null_types(
+	id int primary key,
+	i2 int2,
+	i4 int4,
+	t text,
+	b bool,
+	ts timestamptz,
+	c int8
+)`)
+
+		type row struct {
+			I2 sql.NullInt16
+			I4 pgtype.Int4
+			T  sql.NullString
+			B  pgtype.Bool
+			TS sql.NullTime
+			C  nullableCount
+		}
+
+		validRow := row{
+			I2: sql.NullInt16{Int16: 2, Valid: true},
+			I4: pgtype.Int4{Int32: 5, Valid: true},
+			T:  sql.NullString{String: "foo", Valid: true},
+			B:  pgtype.Bool{Bool: true, Valid: true},
+			TS: sql.NullTime{Time: time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC), Valid: true},
+			C:  nullableCount{n: 42, valid: true},
+		}
+		nullRow := row{}
+
+		for i, r := range []row{validRow, nullRow} {
+			_, err := conn.Exec(ctx, "insert into null_types(id, i2, i4, t, b, ts, c) values ($1, $2, $3, $4, $5, $6, $7)",
+				i, r.I2, r.I4, r.T, r.B, r.TS, r.C)
+			require.NoError(t, err)
+		}
+
+		var nullCount int
+		err := conn.QueryRow(ctx, "select count(*) from null_types where id = 1 and num_nulls(i2, i4, t, b, ts, c) = 6").Scan(&nullCount)
+		require.NoError(t, err)
+		require.Equal(t, 1, nullCount)
+
+		for i, expected := range []row{validRow, nullRow} {
+			var actual row
+			err := conn.QueryRow(ctx, "select i2, i4, t, b, ts, c from null_types where id = $1", i).Scan(
+				&actual.I2, &actual.I4, &actual.T, &actual.B, &actual.TS, &actual.C,
+			)
+			require.NoError(t, err)
+			require.Equal(t, expected.I2, actual.I2)
+			require.Equal(t, expected.I4, actual.I4)
+			require.Equal(t, expected.T, actual.T)
+			require.Equal(t, expected.B, actual.B)
+			require.Equal(t, expected.TS.Valid, actual.TS.Valid)
+			require.True(t, expected.TS.Time.Equal(actual.TS.Time))
+			require.Equal(t, expected.C, actual.C)
+		}
+	})
+}
+
 func TestQueryContextSuccess(t *testing.T) {
 	t.Parallel()
 
@@ -1895,6 +2241,84 @@ func TestConnSimpleProtocolRefusesNonStandardConformingStrings(t *testing.T) {
 	ensureConnValid(t, conn)
 }
 
+func TestConnSimpleQuery(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	rows, err := conn.SimpleQuery(ctx, "select n, n::text, n % 2 = 0 from generate_series(1, 3) n")
+	require.NoError(t, err)
+
+	for _, fd := range rows.FieldDescriptions() {
+		require.EqualValues(t, pgx.TextFormatCode, fd.Format)
+	}
+
+	type row struct {
+		N    int32
+		S    string
+		Even bool
+	}
+	result, err := pgx.CollectRows(rows, pgx.RowToStructByPos[row])
+	require.NoError(t, err)
+	require.Equal(t, []row{{1, "1", false}, {2, "2", true}, {3, "3", false}}, result)
+	require.Equal(t, "SELECT 3", rows.CommandTag().String())
+
+	rows, _ = conn.SimpleQuery(ctx, "select 1 from non_existent_table")
+	rows.Close()
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, rows.Err(), &pgErr)
+	require.Equal(t, "42P01", pgErr.Code)
+
+	ensureConnValid(t, conn)
+}
+
+func TestConnSimpleQueryArgs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	var (
+		n      int64
+		neg    int32
+		s      string
+		b      []byte
+		isNull bool
+		f      float64
+	)
+	rows, err := conn.SimpleQueryArgs(ctx,
+		"select $1::int8, -$2::int4, $3::text, $4::bytea, $5::text is null, $6::float8 -- $3",
+		int64(42), int32(-7), "it's $1 -- not a comment", []byte{0, 1, 255}, nil, 1.5,
+	)
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(&n, &neg, &s, &b, &isNull, &f))
+	require.False(t, rows.Next())
+	require.NoError(t, rows.Err())
+
+	require.EqualValues(t, 42, n)
+	require.EqualValues(t, 7, neg)
+	require.Equal(t, "it's $1 -- not a comment", s)
+	require.Equal(t, []byte{0, 1, 255}, b)
+	require.True(t, isNull)
+	require.Equal(t, 1.5, f)
+
+	rows, err = conn.SimpleQueryArgs(ctx, "select $1::text", "'; drop table users; --")
+	require.NoError(t, err)
+	strs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	require.NoError(t, err)
+	require.Equal(t, []string{"'; drop table users; --"}, strs)
+
+	ensureConnValid(t, conn)
+}
+
 // https://github.com/jackc/pgx/issues/895
 func TestQueryErrorWithDisabledStatementCache(t *testing.T) {
 	t.Parallel()
@@ -2089,3 +2513,78 @@ insert into products (name, price) values
 	// Fries: $5
 	// Soft Drink: $3
 }
+
+func TestConnQueryLimited(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, pgxtest.AllQueryExecModes, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		tx, err := conn.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		rows, _ := conn.QueryLimited(ctx, "select n from generate_series(1, $1::int4) n", 2, 5)
+		numbers, err := pgx.CollectRows(rows, pgx.RowTo[int32])
+		if conn.Config().DefaultQueryExecMode == pgx.QueryExecModeSimpleProtocol {
+			require.Error(t, err)
+			return
+		}
+		require.NoError(t, err)
+		require.Equal(t, []int32{1, 2}, numbers)
+		require.Equal(t, "", rows.CommandTag().String())
+
+		rows, _ = conn.FetchPortal(ctx, "", 2)
+		numbers, err = pgx.CollectRows(rows, pgx.RowTo[int32])
+		require.NoError(t, err)
+		require.Equal(t, []int32{3, 4}, numbers)
+		require.Equal(t, "", rows.CommandTag().String())
+
+		rows, _ = conn.FetchPortal(ctx, "", 2)
+		numbers, err = pgx.CollectRows(rows, pgx.RowTo[int32])
+		require.NoError(t, err)
+		require.Equal(t, []int32{5}, numbers)
+		require.Equal(t, "SELECT 1", rows.CommandTag().String())
+
+		_, err = conn.QueryLimited(ctx, "select 1", 0)
+		require.Error(t, err)
+	})
+}
+
+func TestConnQueryPortal(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	_, err := conn.Prepare(ctx, "numbers", "select n from generate_series($1::int4, $1::int4 + 2) n")
+	require.NoError(t, err)
+
+	tx, err := conn.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+
+	collect := func(rows pgx.Rows, err error) []int32 {
+		require.NoError(t, err)
+		numbers, err := pgx.CollectRows(rows, pgx.RowTo[int32])
+		require.NoError(t, err)
+		return numbers
+	}
+
+	require.Equal(t, []int32{1, 2}, collect(conn.QueryPortal(ctx, "low", "numbers", 2, 1)))
+	require.Equal(t, []int32{100}, collect(conn.QueryPortal(ctx, "high", "numbers", 1, 100)))
+	require.Equal(t, []int32{3}, collect(conn.FetchPortal(ctx, "low", 0)))
+	require.Equal(t, []int32{101, 102}, collect(conn.FetchPortal(ctx, "high", 0)))
+
+	_, err = conn.QueryPortal(ctx, "other", "missing", 0)
+	require.Error(t, err)
+
+	_, err = conn.QueryPortal(ctx, "other", "numbers", 0)
+	require.ErrorIs(t, err, pgx.ErrWrongNumberOfArguments)
+
+	ensureConnValid(t, conn)
+}
@@ -0,0 +1,69 @@
+package pgx
+
+import (
+	"context"
+	"time"
+)
+
+// QueryTimeout is like Query but if the query has not completed within d a cancel request is sent to the server on a
+// separate connection. The query then fails with a *pgconn.PgError with code 57014 (query_canceled) and the connection
+// remains usable. Unlike canceling ctx, which closes the connection, or ConnConfig.DefaultQueryTimeout, which sets
+// statement_timeout, this is entirely client-side and does not require changing any server settings.
+//
+// The timeout includes reading the results. It is stopped when the returned Rows is closed. As with
+// pgconn.PgConn.CancelRequest, there is no guarantee the server cancels the query. e.g. A cancel request that arrives
+// after the query has completed has no effect. Conversely, if the timeout fires just as the query completes, the server
+// may process the cancel request after a later query on the connection has started and cancel that query instead.
+// The PostgreSQL protocol provides no way to prevent this.
+func (c *Conn) QueryTimeout(ctx context.Context, d time.Duration, sql string, args ...any) (Rows, error) {
+	cancelDone := make(chan struct{})
+	timer := time.AfterFunc(d, func() {
+		defer close(cancelDone)
+		c.pgConn.CancelRequest(ctx)
+	})
+
+	rows, err := c.Query(ctx, sql, args...)
+	tr := &timeoutRows{Rows: rows, timer: timer, cancelDone: cancelDone}
+	if err != nil {
+		tr.stopTimer()
+		return rows, err
+	}
+
+	return tr, nil
+}
+
+// timeoutRows stops the cancel request timer of QueryTimeout when the rows are closed.
+type timeoutRows struct {
+	Rows
+	timer      *time.Timer
+	cancelDone chan struct{}
+	stopped    bool
+}
+
+func (rows *timeoutRows) Next() bool {
+	if rows.Rows.Next() {
+		return true
+	}
+
+	rows.stopTimer()
+	return false
+}
+
+func (rows *timeoutRows) Close() {
+	rows.Rows.Close()
+	rows.stopTimer()
+}
+
+// stopTimer stops the timer. If the timer has already fired it waits for the cancel request to be sent. This narrows
+// but does not close the window in which the cancel can affect a later query. The server handles the cancel request
+// asynchronously, so it may still interrupt whatever the connection is running when it is processed.
+func (rows *timeoutRows) stopTimer() {
+	if rows.stopped {
+		return
+	}
+	rows.stopped = true
+
+	if !rows.timer.Stop() {
+		<-rows.cancelDone
+	}
+}
@@ -0,0 +1,68 @@
+package pgx_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnQueryTimeoutCancelsQuery(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	pgxtest.SkipCockroachDB(t, conn, "Server does not support pg_sleep")
+
+	start := time.Now()
+	rows, err := conn.QueryTimeout(ctx, 100*time.Millisecond, "select pg_sleep(10)")
+	require.NoError(t, err)
+	for rows.Next() {
+	}
+	rows.Close()
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	var pgErr *pgconn.PgError
+	require.True(t, errors.As(rows.Err(), &pgErr), "%v", rows.Err())
+	require.Equal(t, "57014", pgErr.Code)
+
+	// Only the query was canceled. The connection is still usable.
+	require.False(t, conn.IsClosed())
+	ensureConnValid(t, conn)
+}
+
+func TestConnQueryTimeoutCompletesBeforeTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	pgxtest.SkipCockroachDB(t, conn, "Server does not support pg_sleep")
+
+	var n int32
+	rows, err := conn.QueryTimeout(ctx, 200*time.Millisecond, "select $1::int4", 42)
+	require.NoError(t, err)
+	for rows.Next() {
+		require.NoError(t, rows.Scan(&n))
+	}
+	require.NoError(t, rows.Err())
+	require.EqualValues(t, 42, n)
+
+	// The timer was stopped when the rows were read so a later query that runs past the timeout is not canceled.
+	_, err = conn.Exec(ctx, "select pg_sleep(0.5)")
+	require.NoError(t, err)
+
+	ensureConnValid(t, conn)
+}
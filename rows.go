@@ -2,6 +2,7 @@ package pgx
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"reflect"
@@ -65,6 +66,9 @@ type Rows interface {
 
 	// RawValues returns the unparsed bytes of the row values. The returned data is only valid until the next Next
 	// call or the Rows is closed.
+	//
+	// The binary format of bytea is the raw bytes. Requesting binary results with QueryResultFormats allows a bytea
+	// value to be written directly to an io.Writer without any decoding or copying.
 	RawValues() [][]byte
 
 	// Conn returns the underlying *Conn on which the query was executed. This may return nil if Rows did not come from a
@@ -384,16 +388,57 @@ func RowsFromResultReader(typeMap *pgtype.Map, resultReader *pgconn.ResultReader
 // ForEachRow iterates through rows. For each row it scans into the elements of scans and calls fn. If any row
 // fails to scan or fn returns an error the query will be aborted and the error will be returned. Rows will be closed
 // when ForEachRow returns.
+//
+// If scans is nil then ForEachRow does not scan and fn is called with rows positioned on the current row. fn may then
+// call rows.Scan itself, e.g. into a local struct.
 func ForEachRow(rows Rows, scans []any, fn func() error) (pgconn.CommandTag, error) {
 	defer rows.Close()
 
 	for rows.Next() {
-		err := rows.Scan(scans...)
+		if scans != nil {
+			err := rows.Scan(scans...)
+			if err != nil {
+				return pgconn.CommandTag{}, err
+			}
+		}
+
+		err := fn()
 		if err != nil {
 			return pgconn.CommandTag{}, err
 		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	return rows.CommandTag(), nil
+}
 
-		err = fn()
+// ForEachRowMap iterates through rows calling fn with a map of each row keyed by column name. The values are decoded as
+// by Rows.Values. Unlike RowToMap, the same map is cleared and reused for every row to reduce allocations when reading
+// large result sets. fn must not retain the map or modify it after fn returns. Copy out any values that are needed. If
+// any row fails to scan or fn returns an error the query will be aborted and the error will be returned. Rows will be
+// closed when ForEachRowMap returns.
+func ForEachRowMap(rows Rows, fn func(row map[string]any) error) (pgconn.CommandTag, error) {
+	defer rows.Close()
+
+	row := make(map[string]any, len(rows.FieldDescriptions()))
+	for rows.Next() {
+		for k := range row {
+			delete(row, k)
+		}
+
+		values, err := rows.Values()
+		if err != nil {
+			return pgconn.CommandTag{}, err
+		}
+
+		for i, fd := range rows.FieldDescriptions() {
+			row[fd.Name] = values[i]
+		}
+
+		err = fn(row)
 		if err != nil {
 			return pgconn.CommandTag{}, err
 		}
@@ -417,12 +462,13 @@ type CollectableRow interface {
 // RowToFunc is a function that scans or otherwise converts row to a T.
 type RowToFunc[T any] func(row CollectableRow) (T, error)
 
-// CollectRows iterates through rows, calling fn for each row, and collecting the results into a slice of T.
-func CollectRows[T any](rows Rows, fn RowToFunc[T]) ([]T, error) {
+// AppendRows iterates through rows, calling fn for each row, and appending the results into a slice of T.
+//
+// When the number of rows is known or can be estimated, passing a slice with preallocated capacity (e.g.
+// make([]T, 0, n)) avoids repeatedly growing the slice while reading a large result.
+func AppendRows[T any, S ~[]T](slice S, rows Rows, fn RowToFunc[T]) (S, error) {
 	defer rows.Close()
 
-	slice := []T{}
-
 	for rows.Next() {
 		value, err := fn(rows)
 		if err != nil {
@@ -438,6 +484,12 @@ func CollectRows[T any](rows Rows, fn RowToFunc[T]) ([]T, error) {
 	return slice, nil
 }
 
+// CollectRows iterates through rows, calling fn for each row, and collecting the results into a slice of T. See
+// AppendRows to collect into a preallocated slice.
+func CollectRows[T any](rows Rows, fn RowToFunc[T]) ([]T, error) {
+	return AppendRows([]T{}, rows, fn)
+}
+
 // CollectOneRow calls fn for the first row in rows and returns the result. If no rows are found returns an error where errors.Is(ErrNoRows) is true.
 // CollectOneRow is to CollectRows as QueryRow is to Query.
 func CollectOneRow[T any](rows Rows, fn RowToFunc[T]) (T, error) {
@@ -509,7 +561,9 @@ func RowToAddrOf[T any](row CollectableRow) (*T, error) {
 	return &value, err
 }
 
-// RowToMap returns a map scanned from row.
+// RowToMap returns a map scanned from row. The map is keyed by column name and the values are decoded as by Rows.Values.
+// If multiple columns have the same name only the last is kept. It may be used with CollectRows or called for each row
+// in a Rows.Next loop to process rows as maps without buffering the entire result.
 func RowToMap(row CollectableRow) (map[string]any, error) {
 	var value map[string]any
 	err := row.Scan((*mapRowScanner)(&value))
@@ -635,9 +689,43 @@ func RowToAddrOfStructByNameLax[T any](row CollectableRow) (*T, error) {
 	return &value, err
 }
 
+// StructScanOptions configures how RowToStructByNameWithOptions and RowToAddrOfStructByNameWithOptions map row fields
+// to struct fields.
+type StructScanOptions struct {
+	// Lax allows T to have more named public fields than the row has fields. See RowToStructByNameLax.
+	Lax bool
+
+	// ErrorOnNullToNonPointer causes a NULL row value to be reported as an error when the corresponding struct field
+	// cannot represent NULL. A field can represent NULL if it is a pointer, slice, map, or interface or if it implements
+	// sql.Scanner (e.g. pgtype.Int4 or sql.NullString). This is useful for catching mismatches between the database
+	// schema and the Go model during development.
+	ErrorOnNullToNonPointer bool
+}
+
+// RowToStructByNameWithOptions returns a RowToFunc that scans a row into a T. T must be a struct. The row and T fields
+// will be matched by name in the same manner as RowToStructByName. opts controls the matching and NULL handling.
+func RowToStructByNameWithOptions[T any](opts StructScanOptions) RowToFunc[T] {
+	return func(row CollectableRow) (T, error) {
+		var value T
+		err := row.Scan(&namedStructRowScanner{ptrToStruct: &value, lax: opts.Lax, errorOnNullToNonPointer: opts.ErrorOnNullToNonPointer})
+		return value, err
+	}
+}
+
+// RowToAddrOfStructByNameWithOptions returns a RowToFunc that scans a row into a *T. T must be a struct. The row and T
+// fields will be matched by name in the same manner as RowToStructByName. opts controls the matching and NULL handling.
+func RowToAddrOfStructByNameWithOptions[T any](opts StructScanOptions) RowToFunc[*T] {
+	return func(row CollectableRow) (*T, error) {
+		var value T
+		err := row.Scan(&namedStructRowScanner{ptrToStruct: &value, lax: opts.Lax, errorOnNullToNonPointer: opts.ErrorOnNullToNonPointer})
+		return &value, err
+	}
+}
+
 type namedStructRowScanner struct {
-	ptrToStruct any
-	lax         bool
+	ptrToStruct             any
+	lax                     bool
+	errorOnNullToNonPointer bool
 }
 
 func (rs *namedStructRowScanner) ScanRow(rows Rows) error {
@@ -659,9 +747,31 @@ func (rs *namedStructRowScanner) ScanRow(rows Rows) error {
 		}
 	}
 
+	if rs.errorOnNullToNonPointer {
+		for i, rawValue := range rows.RawValues() {
+			if rawValue == nil && !canScanNull(scanTargets[i]) {
+				return fmt.Errorf("cannot scan NULL from row field %s into %T", rows.FieldDescriptions()[i].Name, scanTargets[i])
+			}
+		}
+	}
+
 	return rows.Scan(scanTargets...)
 }
 
+// canScanNull returns true if the value pointed to by dst can represent NULL.
+func canScanNull(dst any) bool {
+	if _, ok := dst.(sql.Scanner); ok {
+		return true
+	}
+
+	switch reflect.TypeOf(dst).Elem().Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return true
+	}
+
+	return false
+}
+
 const structTagKey = "db"
 
 func fieldPosByName(fldDescs []pgconn.FieldDescription, field string) (i int) {
@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -129,6 +131,44 @@ func TestForEachRowAbort(t *testing.T) {
 	})
 }
 
+func TestForEachRowNilScans(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		type point struct {
+			X int32
+			Y int32
+		}
+		var points []point
+
+		rows, _ := conn.Query(ctx, "select n, n * 2 from generate_series(1, $1) n", 3)
+		ct, err := pgx.ForEachRow(rows, nil, func() error {
+			var p point
+			err := rows.Scan(&p.X, &p.Y)
+			if err != nil {
+				return err
+			}
+			points = append(points, p)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []point{{1, 2}, {2, 4}, {3, 6}}, points)
+		require.EqualValues(t, 3, ct.RowsAffected())
+
+		rows, _ = conn.Query(ctx, "select 'foo' from generate_series(1, $1) n", 3)
+		ct, err = pgx.ForEachRow(rows, nil, func() error {
+			var n int32
+			return rows.Scan(&n)
+		})
+		require.ErrorContains(t, err, "cannot scan text (OID 25) in text format into *int32")
+		require.Equal(t, pgconn.CommandTag{}, ct)
+		ensureConnValid(t, conn)
+	})
+}
+
 func ExampleForEachRow() {
 	conn, err := pgx.Connect(context.Background(), os.Getenv("PGX_TEST_DATABASE"))
 	if err != nil {
@@ -157,6 +197,44 @@ func ExampleForEachRow() {
 	// 3, 6
 }
 
+// This example uses ForEachRow with nil scans so the callback can scan each row into a local struct.
+func ExampleForEachRow_scanInCallback() {
+	conn, err := pgx.Connect(context.Background(), os.Getenv("PGX_TEST_DATABASE"))
+	if err != nil {
+		fmt.Printf("Unable to establish connection: %v", err)
+		return
+	}
+
+	type point struct {
+		X int32
+		Y int32
+	}
+
+	rows, _ := conn.Query(
+		context.Background(),
+		"select n, n * 2 from generate_series(1, $1) n",
+		3,
+	)
+	_, err = pgx.ForEachRow(rows, nil, func() error {
+		var p point
+		err := rows.Scan(&p.X, &p.Y)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%+v\n", p)
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("ForEachRow error: %v", err)
+		return
+	}
+
+	// Output:
+	// {X:1 Y:2}
+	// {X:2 Y:4}
+	// {X:3 Y:6}
+}
+
 func TestCollectRows(t *testing.T) {
 	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
 		rows, _ := conn.Query(ctx, `select n from generate_series(0, 99) n`)
@@ -174,6 +252,27 @@ func TestCollectRows(t *testing.T) {
 	})
 }
 
+func TestAppendRows(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		numbers := make([]int32, 0, 100)
+		rows, _ := conn.Query(ctx, `select n from generate_series(0, 99) n`)
+		numbers, err := pgx.AppendRows(numbers, rows, pgx.RowTo[int32])
+		require.NoError(t, err)
+
+		assert.Len(t, numbers, 100)
+		assert.Equal(t, 100, cap(numbers))
+		for i := range numbers {
+			assert.Equal(t, int32(i), numbers[i])
+		}
+
+		rows, _ = conn.Query(ctx, `select n from generate_series(100, 101) n`)
+		numbers, err = pgx.AppendRows(numbers, rows, pgx.RowTo[int32])
+		require.NoError(t, err)
+		assert.Len(t, numbers, 102)
+		assert.Equal(t, int32(101), numbers[101])
+	})
+}
+
 // This example uses CollectRows with a manually written collector function. In most cases RowTo, RowToAddrOf,
 // RowToStructByPos, RowToAddrOfStructByPos, or another generic function would be used.
 func ExampleCollectRows() {
@@ -405,6 +504,62 @@ func TestRowToMap(t *testing.T) {
 	})
 }
 
+func TestRowToMapWhileIterating(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		rows, _ := conn.Query(ctx, `select n as id, 'name ' || n as name, n % 2 = 0 as even, null::text as missing from generate_series(1, 3) n`)
+		defer rows.Close()
+
+		var count int32
+		for rows.Next() {
+			count++
+			m, err := pgx.RowToMap(rows)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{
+				"id":      count,
+				"name":    fmt.Sprintf("name %d", count),
+				"even":    count%2 == 0,
+				"missing": nil,
+			}, m)
+		}
+		require.NoError(t, rows.Err())
+		assert.EqualValues(t, 3, count)
+	})
+}
+
+func TestForEachRowMap(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		rows, _ := conn.Query(ctx, `select n as id, 'name ' || n as name, null::text as missing from generate_series(1, 3) n`)
+
+		var maps []uintptr
+		var names []string
+		ct, err := pgx.ForEachRowMap(rows, func(row map[string]any) error {
+			maps = append(maps, reflect.ValueOf(row).Pointer())
+			assert.Len(t, row, 3)
+			assert.Nil(t, row["missing"])
+			names = append(names, row["name"].(string))
+
+			// Keys added by fn are cleared before the next row.
+			assert.NotContains(t, row, "extra")
+			row["extra"] = true
+			return nil
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 3, ct.RowsAffected())
+		require.Equal(t, []string{"name 1", "name 2", "name 3"}, names)
+
+		// The same map is reused for every row.
+		require.Len(t, maps, 3)
+		require.Equal(t, maps[0], maps[1])
+		require.Equal(t, maps[0], maps[2])
+
+		rows, _ = conn.Query(ctx, `select n from generate_series(1, 3) n`)
+		_, err = pgx.ForEachRowMap(rows, func(row map[string]any) error {
+			return errors.New("fn failed")
+		})
+		require.EqualError(t, err, "fn failed")
+	})
+}
+
 func TestRowToStructByPos(t *testing.T) {
 	type person struct {
 		Name string
@@ -880,6 +1035,29 @@ func TestRowToStructByNameLaxRowValue(t *testing.T) {
 	})
 }
 
+func TestRowToStructByNameWithOptionsErrorOnNullToNonPointer(t *testing.T) {
+	type person struct {
+		Name     string
+		Nickname *string
+		Age      pgtype.Int4
+	}
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		opts := pgx.StructScanOptions{ErrorOnNullToNonPointer: true}
+
+		rows, _ := conn.Query(ctx, `select 'John' as name, null::text as nickname, null::int4 as age`)
+		p, err := pgx.CollectOneRow(rows, pgx.RowToStructByNameWithOptions[person](opts))
+		require.NoError(t, err)
+		assert.Equal(t, "John", p.Name)
+		assert.Nil(t, p.Nickname)
+		assert.False(t, p.Age.Valid)
+
+		rows, _ = conn.Query(ctx, `select null::text as name, null::text as nickname, 42 as age`)
+		_, err = pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByNameWithOptions[person](opts))
+		assert.ErrorContains(t, err, "cannot scan NULL from row field name into *string")
+	})
+}
+
 func ExampleRowToStructByNameLax() {
 	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
 	defer cancel()
@@ -524,6 +524,16 @@ func TestConnQueryScanArray(t *testing.T) {
 	})
 }
 
+func TestConnQueryScanIndexKey(t *testing.T) {
+	testWithAllQueryExecModes(t, func(t *testing.T, db *sql.DB) {
+		var indkey, indclass string
+		err := db.QueryRow("select '3 1'::int2vector, '23 25'::oidvector").Scan(&indkey, &indclass)
+		require.NoError(t, err)
+		assert.Equal(t, "3 1", indkey)
+		assert.Equal(t, "23 25", indclass)
+	})
+}
+
 func TestConnQueryScanRange(t *testing.T) {
 	testWithAllQueryExecModes(t, func(t *testing.T, db *sql.DB) {
 		skipCockroachDB(t, db, "Server does not support int4range")
@@ -2,6 +2,7 @@ package pgx_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -520,6 +521,43 @@ func TestTracePrepare(t *testing.T) {
 	})
 }
 
+func TestTracePrepareBatch(t *testing.T) {
+	t.Parallel()
+
+	tracer := &testTracer{}
+
+	config := defaultConnTestRunner.CreateConfig(context.Background(), t)
+	config.Tracer = tracer
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnect(t, config)
+	defer closeConn(t, conn)
+
+	var started []string
+	tracer.tracePrepareStart = func(ctx context.Context, conn *pgx.Conn, data pgx.TracePrepareStartData) context.Context {
+		started = append(started, data.Name+": "+data.SQL)
+		return context.WithValue(ctx, ctxKey("fromTracePrepareStart"), data.Name)
+	}
+
+	var ended []string
+	tracer.tracePrepareEnd = func(ctx context.Context, conn *pgx.Conn, data pgx.TracePrepareEndData) {
+		ended = append(ended, fmt.Sprintf("%v: %v %v", ctx.Value(ctxKey("fromTracePrepareStart")), data.AlreadyPrepared, data.Err != nil))
+	}
+
+	err := conn.PrepareBatch(ctx, map[string]string{"a": "select 1", "b": "select 2"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a: select 1", "b: select 2"}, started)
+	require.Equal(t, []string{"a: false false", "b: false false"}, ended)
+
+	started, ended = nil, nil
+	err = conn.PrepareBatch(ctx, map[string]string{"a": "select 1", "c": "selct"})
+	require.Error(t, err)
+	require.Equal(t, []string{"a: select 1", "c: selct"}, started)
+	require.Equal(t, []string{"a: true false", "c: false true"}, ended)
+}
+
 func TestTraceConnect(t *testing.T) {
 	t.Parallel()
 
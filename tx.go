@@ -375,6 +375,42 @@ func (sp *dbSimulatedNestedTx) Conn() *Conn {
 	return sp.tx.Conn()
 }
 
+// DeclareCursor declares a server-side cursor named name for sql in tx with DECLARE ... CURSOR FOR. The cursor exists
+// until it is closed with CloseCursor or the transaction ends.
+func DeclareCursor(ctx context.Context, tx Tx, name, sql string, args ...any) error {
+	_, err := tx.Exec(ctx, declareCursorSQL(name, sql), args...)
+	return err
+}
+
+// FetchCursor fetches up to n rows from the cursor named name in tx with FETCH n FROM. An empty result means the cursor
+// is exhausted. n must be greater than 0.
+func FetchCursor(ctx context.Context, tx Tx, name string, n int) (Rows, error) {
+	if n <= 0 {
+		err := fmt.Errorf("cannot fetch %d rows from cursor %q: n must be greater than 0", n, name)
+		return &baseRows{err: err, closed: true}, err
+	}
+
+	return tx.Query(ctx, fetchCursorSQL(name, n))
+}
+
+// CloseCursor closes the cursor named name in tx with CLOSE.
+func CloseCursor(ctx context.Context, tx Tx, name string) error {
+	_, err := tx.Exec(ctx, closeCursorSQL(name))
+	return err
+}
+
+func declareCursorSQL(name, sql string) string {
+	return "declare " + quoteIdentifier(name) + " cursor for " + sql
+}
+
+func fetchCursorSQL(name string, n int) string {
+	return "fetch " + strconv.Itoa(n) + " from " + quoteIdentifier(name)
+}
+
+func closeCursorSQL(name string) string {
+	return "close " + quoteIdentifier(name)
+}
+
 // BeginFunc calls Begin on db and then calls fn. If fn does not return an error then it calls Commit on db. If fn
 // returns an error it calls Rollback on db. The context will be used when executing the transaction control statements
 // (BEGIN, ROLLBACK, and COMMIT) but does not otherwise affect the execution of fn.
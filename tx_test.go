@@ -642,3 +642,57 @@ func TestTxSendBatchClosed(t *testing.T) {
 	_, err = br.Query()
 	require.Error(t, err)
 }
+
+func TestFetchCursorRejectsNonPositiveCount(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{0, -1} {
+		// The count is checked before tx is used.
+		rows, err := pgx.FetchCursor(context.Background(), nil, "test cursor", n)
+		require.ErrorContainsf(t, err, "n must be greater than 0", "%d", n)
+		require.False(t, rows.Next())
+		require.Equal(t, err, rows.Err())
+		rows.Close()
+	}
+}
+
+func TestTxCursor(t *testing.T) {
+	t.Parallel()
+
+	db := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, db)
+
+	ctx := context.Background()
+
+	tx, err := db.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+
+	err = pgx.DeclareCursor(ctx, tx, "test cursor", "select n from generate_series(1, $1::int) n", 10)
+	require.NoError(t, err)
+
+	var fetched []int32
+	for {
+		rows, err := pgx.FetchCursor(ctx, tx, "test cursor", 4)
+		require.NoError(t, err)
+		page, err := pgx.CollectRows(rows, pgx.RowTo[int32])
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		require.LessOrEqual(t, len(page), 4)
+		fetched = append(fetched, page...)
+	}
+	require.Equal(t, []int32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, fetched)
+
+	err = pgx.CloseCursor(ctx, tx, "test cursor")
+	require.NoError(t, err)
+
+	_, err = pgx.FetchCursor(ctx, tx, "test cursor", 1)
+	require.Error(t, err)
+
+	err = tx.Rollback(ctx)
+	require.NoError(t, err)
+
+	ensureConnValid(t, db)
+}